@@ -0,0 +1,873 @@
+package builder
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/cache"
+	"github.com/google/go-containerregistry/pkg/v1/daemon"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// FileSystem interface for file operations
+type FileSystem interface {
+	Stat(name string) (fs.FileInfo, error)
+	ReadFile(name string) ([]byte, error)
+}
+
+// BinaryProvider interface for accessing server binaries
+type BinaryProvider interface {
+	ExtractServerBinary(platform *v1.Platform) ([]byte, fs.FileInfo, error)
+}
+
+// ImageDownloader interface for downloading base images
+type ImageDownloader interface {
+	// DownloadImage downloads baseImage for platform. If progress is
+	// non-nil, per-byte download updates are sent to it as they occur;
+	// callers must drain it to avoid blocking the download.
+	DownloadImage(ctx context.Context, baseImage string, platform *v1.Platform, progress chan<- v1.Update) (v1.Image, error)
+}
+
+// ImageSaver interface for saving built images to different destinations
+type ImageSaver interface {
+	SaveImage(ctx context.Context, img v1.Image, ref string) error
+	SaveImageIndex(ctx context.Context, idx v1.ImageIndex, ref string) error
+}
+
+// OSFileSystem implements FileSystem using the standard os package
+type OSFileSystem struct{}
+
+func (fs *OSFileSystem) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (fs *OSFileSystem) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+// EmbedBinaryProvider implements BinaryProvider using embedded binaries
+type EmbedBinaryProvider struct {
+	binaries embed.FS
+}
+
+func (bp *EmbedBinaryProvider) ExtractServerBinary(platform *v1.Platform) ([]byte, fs.FileInfo, error) {
+	filename := fmt.Sprintf("binaries/genmcp-server-%s-%s", platform.OS, platform.Architecture)
+	if platform.OS == "windows" {
+		filename += ".exe"
+	}
+
+	fileInfo, err := fs.Stat(bp.binaries, filename)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	binary, err := bp.binaries.ReadFile(filename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("no binary found for platform %s/%s", platform.OS, platform.Architecture)
+	}
+
+	return binary, fileInfo, nil
+}
+
+// DefaultImageDownloader implements ImageDownloader using go-containerregistry
+type DefaultImageDownloader struct {
+	// Keychain resolves registry credentials for pulling the base image.
+	// Defaults to DefaultKeychain when nil.
+	Keychain authn.Keychain
+}
+
+func (d *DefaultImageDownloader) keychain() authn.Keychain {
+	if d.Keychain != nil {
+		return d.Keychain
+	}
+	return DefaultKeychain
+}
+
+// RegistryImageSaver implements ImageSaver for pushing to container registries
+type RegistryImageSaver struct {
+	// Keychain resolves registry credentials. Defaults to DefaultKeychain
+	// when nil.
+	Keychain authn.Keychain
+}
+
+func (r *RegistryImageSaver) keychain() authn.Keychain {
+	if r.Keychain != nil {
+		return r.Keychain
+	}
+	return DefaultKeychain
+}
+
+// DaemonImageSaver implements ImageSaver for saving to local container engine
+type DaemonImageSaver struct{}
+
+func (d *DefaultImageDownloader) DownloadImage(ctx context.Context, baseImage string, platform *v1.Platform, progress chan<- v1.Update) (v1.Image, error) {
+	ref, err := name.ParseReference(baseImage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base image name %s: %w", baseImage, err)
+	}
+
+	authOpt, err := resolveAuthOption(ctx, d.keychain())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve registry credentials for %s: %w", baseImage, err)
+	}
+
+	img, err := remote.Image(ref,
+		remote.WithContext(ctx),
+		remote.WithPlatform(*platform),
+		remoteAuthOptionFor(authOpt),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull base image %s, %w", baseImage, err)
+	}
+
+	if progress == nil {
+		return img, nil
+	}
+
+	img, err = fetchLayersWithProgress(img, progress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull base image %s, %w", baseImage, err)
+	}
+
+	return img, nil
+}
+
+// fetchLayersWithProgress eagerly reads every layer of img, reporting
+// cumulative byte progress to progress as it goes, and returns an image that
+// serves those layers from memory instead of refetching them from the
+// registry. This is needed because go-containerregistry only wires
+// remote.WithProgress into its push path (remote.Write), never into
+// remote.Image's lazy pull, so passing it there has no effect at all.
+func fetchLayersWithProgress(img v1.Image, progress chan<- v1.Update) (v1.Image, error) {
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image layers: %w", err)
+	}
+
+	var total int64
+	for _, layer := range layers {
+		size, err := layer.Size()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get layer size: %w", err)
+		}
+		total += size
+	}
+
+	cached := cache.Image(img, newMemoryLayerCache(progress, total))
+
+	cachedLayers, err := cached.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image layers: %w", err)
+	}
+
+	for _, layer := range cachedLayers {
+		if err := drainLayer(layer); err != nil {
+			return nil, err
+		}
+	}
+
+	return cached, nil
+}
+
+func drainLayer(layer v1.Layer) error {
+	rc, err := layer.Compressed()
+	if err != nil {
+		return fmt.Errorf("failed to fetch layer: %w", err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	if _, err := io.Copy(io.Discard, rc); err != nil {
+		return fmt.Errorf("failed to fetch layer: %w", err)
+	}
+
+	return nil
+}
+
+// memoryLayerCache is a cache.Cache that buffers each layer's compressed
+// bytes in memory as it's read for the first time (via the io.Reader
+// returned by Compressed), reporting cumulative progress to updates as it
+// does so. Later reads of the same digest are served from memory.
+type memoryLayerCache struct {
+	mu       sync.Mutex
+	layers   map[v1.Hash][]byte
+	updates  chan<- v1.Update
+	total    int64
+	complete int64
+}
+
+func newMemoryLayerCache(updates chan<- v1.Update, total int64) *memoryLayerCache {
+	return &memoryLayerCache{layers: make(map[v1.Hash][]byte), updates: updates, total: total}
+}
+
+func (c *memoryLayerCache) Get(h v1.Hash) (v1.Layer, error) {
+	c.mu.Lock()
+	data, ok := c.layers[h]
+	c.mu.Unlock()
+	if !ok {
+		return nil, cache.ErrNotFound
+	}
+
+	return static.NewLayer(data, types.DockerLayer), nil
+}
+
+func (c *memoryLayerCache) Put(l v1.Layer) (v1.Layer, error) {
+	return &memoryCachingLayer{inner: l, cache: c}, nil
+}
+
+func (c *memoryLayerCache) Delete(h v1.Hash) error {
+	c.mu.Lock()
+	delete(c.layers, h)
+	c.mu.Unlock()
+	return nil
+}
+
+// memoryCachingLayer wraps a v1.Layer so that reading it through Compressed
+// buffers the bytes into the cache and reports progress, while every other
+// method simply delegates to the wrapped layer.
+type memoryCachingLayer struct {
+	inner v1.Layer
+	cache *memoryLayerCache
+}
+
+func (l *memoryCachingLayer) Compressed() (io.ReadCloser, error) {
+	digest, err := l.inner.Digest()
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := l.inner.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rc.Close() }()
+
+	data, err := io.ReadAll(&progressReader{r: rc, cache: l.cache})
+	if err != nil {
+		return nil, err
+	}
+
+	l.cache.mu.Lock()
+	l.cache.layers[digest] = data
+	l.cache.mu.Unlock()
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (l *memoryCachingLayer) Uncompressed() (io.ReadCloser, error) { return l.inner.Uncompressed() }
+func (l *memoryCachingLayer) Size() (int64, error)                 { return l.inner.Size() }
+func (l *memoryCachingLayer) DiffID() (v1.Hash, error)             { return l.inner.DiffID() }
+func (l *memoryCachingLayer) Digest() (v1.Hash, error)             { return l.inner.Digest() }
+func (l *memoryCachingLayer) MediaType() (types.MediaType, error)  { return l.inner.MediaType() }
+
+// progressReader reports cumulative bytes read from r to cache.updates as
+// "Downloading" progress, so a slow consumer never blocks the download: a
+// full channel just means that update is skipped.
+type progressReader struct {
+	r     io.Reader
+	cache *memoryLayerCache
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.cache.mu.Lock()
+		p.cache.complete += int64(n)
+		complete := p.cache.complete
+		p.cache.mu.Unlock()
+
+		select {
+		case p.cache.updates <- v1.Update{Complete: complete, Total: p.cache.total}:
+		default:
+		}
+	}
+	return n, err
+}
+
+func (r *RegistryImageSaver) SaveImage(ctx context.Context, img v1.Image, ref string) error {
+	repo, err := name.ParseReference(ref)
+	if err != nil {
+		return fmt.Errorf("invalid reference %s: %w", ref, err)
+	}
+
+	authOpt, err := resolveAuthOption(ctx, r.keychain())
+	if err != nil {
+		return fmt.Errorf("failed to resolve registry credentials for %s: %w", ref, err)
+	}
+
+	if err = remote.Write(repo, img,
+		remote.WithContext(ctx),
+		remoteAuthOptionFor(authOpt),
+	); err != nil {
+		return fmt.Errorf("failed to push image to %s: %w", ref, err)
+	}
+
+	return nil
+}
+
+func (r *RegistryImageSaver) SaveImageIndex(ctx context.Context, idx v1.ImageIndex, ref string) error {
+	repo, err := name.ParseReference(ref)
+	if err != nil {
+		return fmt.Errorf("invalid reference %s: %w", ref, err)
+	}
+
+	authOpt, err := resolveAuthOption(ctx, r.keychain())
+	if err != nil {
+		return fmt.Errorf("failed to resolve registry credentials for %s: %w", ref, err)
+	}
+
+	if err = remote.WriteIndex(repo, idx,
+		remote.WithContext(ctx),
+		remoteAuthOptionFor(authOpt),
+	); err != nil {
+		return fmt.Errorf("failed to push image index to %s: %w", ref, err)
+	}
+
+	return nil
+}
+
+func remoteAuthOptionFor(opt remoteAuthOption) remote.Option {
+	if opt.authenticator != nil {
+		return remote.WithAuth(opt.authenticator)
+	}
+	return remote.WithAuthFromKeychain(opt.keychain)
+}
+
+func (d *DaemonImageSaver) SaveImage(ctx context.Context, img v1.Image, ref string) error {
+	tag, err := name.NewTag(ref)
+	if err != nil {
+		return fmt.Errorf("failed to parse tag %s: %w", ref, err)
+	}
+
+	_, err = daemon.Write(tag, img, daemon.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to save image to local container engine: %w", err)
+	}
+
+	return nil
+}
+
+// SaveImageIndex saves each platform-specific image to the local daemon
+// under a platform-suffixed tag, since the local container engine doesn't
+// support manifest lists. The image matching the host platform (or the
+// first one, if no match is found) is additionally saved under ref itself.
+func (d *DaemonImageSaver) SaveImageIndex(ctx context.Context, idx v1.ImageIndex, ref string) error {
+	manifest, err := idx.IndexManifest()
+	if err != nil {
+		return fmt.Errorf("failed to get index manifest: %w", err)
+	}
+
+	baseTag, err := name.NewTag(ref)
+	if err != nil {
+		return fmt.Errorf("failed to parse tag %s: %w", ref, err)
+	}
+
+	var imageForBaseTag v1.Image
+
+	for _, desc := range manifest.Manifests {
+		if desc.Platform == nil {
+			continue
+		}
+
+		img, err := idx.Image(desc.Digest)
+		if err != nil {
+			return fmt.Errorf("failed to get image for platform %s/%s: %w", desc.Platform.OS, desc.Platform.Architecture, err)
+		}
+
+		if imageForBaseTag == nil || (desc.Platform.OS == runtime.GOOS && desc.Platform.Architecture == runtime.GOARCH) {
+			imageForBaseTag = img
+		}
+
+		platformTag, err := name.NewTag(fmt.Sprintf("%s-%s-%s", baseTag.String(), desc.Platform.OS, desc.Platform.Architecture))
+		if err != nil {
+			return fmt.Errorf("failed to create platform tag: %w", err)
+		}
+
+		if _, err = daemon.Write(platformTag, img, daemon.WithContext(ctx)); err != nil {
+			return fmt.Errorf("failed to save image for platform %s/%s to local container engine: %w", desc.Platform.OS, desc.Platform.Architecture, err)
+		}
+	}
+
+	if imageForBaseTag != nil {
+		if _, err = daemon.Write(baseTag, imageForBaseTag, daemon.WithContext(ctx)); err != nil {
+			return fmt.Errorf("failed to save image to local container engine: %w", err)
+		}
+	}
+
+	return nil
+}
+
+//go:embed binaries/genmcp-server-*
+var serverBinaries embed.FS
+
+// Magic value required to make file exexutable in windows containers
+// taken from https://github.com/ko-build/ko/blob/4cee0bb4ee9655f43cc2ef26dbe0f45fac1eda5c/pkg/build/gobuild.go#L591
+const userOwnerAndGroupSID = "AQAAgBQAAAAkAAAAAAAAAAAAAAABAgAAAAAABSAAAAAhAgAAAQIAAAAAAAUgAAAAIQIAAA=="
+
+// various standard oci labels
+const (
+	ImageTitleLabel       = "org.opencontainers.image.title"
+	ImageDescriptionLabel = "org.opencontainers.image.description"
+	ImageCreatedLabel     = "org.opencontainers.image.created"
+	ImageRefNameLabel     = "org.opencontainers.image.ref.name"
+	ImageVersionLabel     = "org.opencontainers.image.version"
+)
+
+type ImageBuilder struct {
+	fs              FileSystem
+	binaryProvider  BinaryProvider
+	imageDownloader ImageDownloader
+	imageSaver      ImageSaver
+	// signer is used by SaveAndSign. When nil, a KeySigner built from the
+	// BuildOptions.SigningOptions passed to SaveAndSign is used instead.
+	signer Signer
+	// encrypter is used by Build when BuildOptions.EncryptionConfig has
+	// recipients. When nil, an AESEncrypter is used instead.
+	encrypter Encrypter
+}
+
+func New(saveToRegistry bool) *ImageBuilder {
+	var saver ImageSaver
+	if saveToRegistry {
+		saver = &RegistryImageSaver{}
+	} else {
+		saver = &DaemonImageSaver{}
+	}
+
+	return &ImageBuilder{
+		fs:              &OSFileSystem{},
+		binaryProvider:  &EmbedBinaryProvider{binaries: serverBinaries},
+		imageDownloader: &DefaultImageDownloader{},
+		imageSaver:      saver,
+	}
+}
+
+func (b *ImageBuilder) Build(ctx context.Context, opts BuildOptions) (v1.Image, error) {
+	opts.SetDefaults()
+	ctx = withRegistryAuth(ctx, opts.RegistryAuth)
+
+	emitter := newProgressEmitter(opts.ProgressWriter, opts.SuppressOutput)
+
+	baseImg, err := b.downloadBaseImageWithProgress(ctx, opts, emitter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download base image: %w", err)
+	}
+
+	emitter.stream(fmt.Sprintf("extracting genmcp-server binary for %s/%s\n", opts.Platform.OS, opts.Platform.Architecture))
+	serverBinary, serverBinaryInfo, err := b.binaryProvider.ExtractServerBinary(opts.Platform)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract server binary: %w", err)
+	}
+
+	mcpFileInfo, err := b.fs.Stat(opts.MCPFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat MCPFile: %w", err)
+	}
+
+	mcpFileData, err := b.fs.ReadFile(opts.MCPFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read MCPFile: %w", err)
+	}
+
+	mediaType, err := b.getLayerMediaType(baseImg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media type for layers: %w", err)
+	}
+
+	binaryLayer, err := b.createBinaryLayer(serverBinary, serverBinaryInfo, opts.Platform, mediaType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create layer for genmcp-server binary: %w", err)
+	}
+
+	emitter.stream("adding mcpfile.yaml layer\n")
+	mcpFileLayer, err := b.createMCPFileLayer(mcpFileData, mcpFileInfo, opts.Platform, mediaType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create layer for mcpfile.yaml: %w", err)
+	}
+
+	binaryAddendum := mutate.Addendum{Layer: binaryLayer}
+	mcpFileAddendum := mutate.Addendum{Layer: mcpFileLayer}
+
+	if len(opts.EncryptionConfig.Recipients) > 0 {
+		encrypter := b.encrypter
+		if encrypter == nil {
+			encrypter = &AESEncrypter{}
+		}
+
+		emitter.stream("encrypting mcpfile.yaml layer\n")
+		encLayer, annotations, err := encrypter.EncryptLayer(mcpFileLayer, opts.EncryptionConfig.Recipients)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt mcpfile.yaml layer: %w", err)
+		}
+		mcpFileAddendum = mutate.Addendum{Layer: encLayer, Annotations: annotations}
+
+		if opts.EncryptionConfig.EncryptBinary {
+			emitter.stream("encrypting genmcp-server binary layer\n")
+			encBinaryLayer, binaryAnnotations, err := encrypter.EncryptLayer(binaryLayer, opts.EncryptionConfig.Recipients)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encrypt genmcp-server binary layer: %w", err)
+			}
+			binaryAddendum = mutate.Addendum{Layer: encBinaryLayer, Annotations: binaryAnnotations}
+		}
+	}
+
+	emitter.stream("updating image configuration\n")
+	img, err := b.assembleImage(baseImg, opts, binaryAddendum, mcpFileAddendum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assemble final image: %w", err)
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image digest: %w", err)
+	}
+	emitter.aux(digest.String())
+
+	return img, nil
+}
+
+// downloadBaseImageWithProgress downloads opts.BaseImage, forwarding
+// per-byte download updates to emitter as "Downloading" status events until
+// the download completes.
+func (b *ImageBuilder) downloadBaseImageWithProgress(ctx context.Context, opts BuildOptions, emitter *progressEmitter) (v1.Image, error) {
+	emitter.stream(fmt.Sprintf("pulling base image %s\n", opts.BaseImage))
+
+	updates := make(chan v1.Update, 50)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for u := range updates {
+			if u.Error != nil {
+				continue
+			}
+			emitter.status("Downloading", "base-image", u.Complete, u.Total)
+		}
+	}()
+
+	img, err := b.imageDownloader.DownloadImage(ctx, opts.BaseImage, opts.Platform, updates)
+	close(updates)
+	<-done
+
+	return img, err
+}
+
+func (b *ImageBuilder) Save(ctx context.Context, img v1.Image, ref string) error {
+	return b.imageSaver.SaveImage(ctx, img, ref)
+}
+
+// SaveIndex saves an OCI image index built by BuildMultiArch.
+func (b *ImageBuilder) SaveIndex(ctx context.Context, idx v1.ImageIndex, ref string) error {
+	return b.imageSaver.SaveImageIndex(ctx, idx, ref)
+}
+
+// SaveIndexTo saves idx to ref, dispatching to the local saver selected by
+// opts.Output when set, or falling back to SaveIndex otherwise.
+func (b *ImageBuilder) SaveIndexTo(ctx context.Context, idx v1.ImageIndex, ref string, opts MultiArchBuildOptions) error {
+	saver, err := parseOutputSaver(opts.Output)
+	if err != nil {
+		return err
+	}
+	if saver == nil {
+		return b.SaveIndex(ctx, idx, ref)
+	}
+
+	return saver.SaveImageIndex(ctx, idx, ref)
+}
+
+// SaveAndSign saves img to ref, dispatching to the local saver selected by
+// opts.Output when set, and, if opts.Sign is set, signs it afterwards using
+// opts.SigningOptions.
+func (b *ImageBuilder) SaveAndSign(ctx context.Context, img v1.Image, ref string, opts BuildOptions) error {
+	saver, err := parseOutputSaver(opts.Output)
+	if err != nil {
+		return err
+	}
+
+	authCtx := withRegistryAuth(ctx, opts.RegistryAuth)
+
+	if saver != nil {
+		if err := saver.SaveImage(authCtx, img, ref); err != nil {
+			return err
+		}
+	} else if err := b.Save(authCtx, img, ref); err != nil {
+		return err
+	}
+
+	if !opts.Sign {
+		return nil
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return fmt.Errorf("failed to get image digest for signing: %w", err)
+	}
+
+	signer := b.signer
+	if signer == nil {
+		signer = NewKeySigner(opts.SigningOptions)
+	}
+
+	if err := signer.SignImage(authCtx, ref, digest); err != nil {
+		return fmt.Errorf("failed to sign image: %w", err)
+	}
+
+	return nil
+}
+
+// maxConcurrentPlatformBuilds bounds how many platforms BuildMultiArch builds
+// at once, so a large Platforms list doesn't spawn unbounded goroutines
+// pulling base images and extracting binaries in parallel.
+const maxConcurrentPlatformBuilds = 4
+
+// BuildMultiArch builds one image per platform in opts.Platforms, in
+// parallel (bounded by maxConcurrentPlatformBuilds), and assembles them into
+// a single OCI image index (manifest list), so that a single tag resolves to
+// the right image on every requested platform.
+func (b *ImageBuilder) BuildMultiArch(ctx context.Context, opts MultiArchBuildOptions) (v1.ImageIndex, error) {
+	opts.SetDefaults()
+
+	images := make([]v1.Image, len(opts.Platforms))
+	errs := make([]error, len(opts.Platforms))
+
+	// Every platform's progressEmitter would otherwise write to the same
+	// opts.ProgressWriter concurrently; serializedWriter funnels their writes
+	// through a single goroutine so concurrent platforms can safely share it.
+	var progressWriter io.Writer
+	if opts.ProgressWriter != nil {
+		sw := newSerializedWriter(opts.ProgressWriter)
+		defer sw.Close()
+		progressWriter = sw
+	}
+
+	sem := make(chan struct{}, maxConcurrentPlatformBuilds)
+	var wg sync.WaitGroup
+
+	for i, platform := range opts.Platforms {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, platform *v1.Platform) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			buildOpts := BuildOptions{
+				Platform:       platform,
+				BaseImage:      opts.BaseImage,
+				MCPFilePath:    opts.MCPFilePath,
+				ImageTag:       opts.ImageTag,
+				ProgressWriter: progressWriter,
+				SuppressOutput: opts.SuppressOutput,
+			}
+
+			img, err := b.Build(ctx, buildOpts)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to build image for platform %s/%s: %w", platform.OS, platform.Architecture, err)
+				return
+			}
+
+			images[i] = img
+		}(i, platform)
+	}
+	wg.Wait()
+
+	var adds []mutate.IndexAddendum
+	allOCI := true
+
+	for i, platform := range opts.Platforms {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+
+		mt, err := images[i].MediaType()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get media type for platform %s/%s: %w", platform.OS, platform.Architecture, err)
+		}
+		if mt != types.OCIManifestSchema1 {
+			allOCI = false
+		}
+
+		adds = append(adds, mutate.IndexAddendum{
+			Add: images[i],
+			Descriptor: v1.Descriptor{
+				Platform: platform,
+			},
+		})
+	}
+
+	indexMediaType := types.DockerManifestList
+	if allOCI {
+		indexMediaType = types.OCIImageIndex
+	}
+
+	idx := mutate.IndexMediaType(empty.Index, indexMediaType)
+	idx = mutate.AppendManifests(idx, adds...)
+
+	return idx, nil
+}
+
+func (b *ImageBuilder) getLayerMediaType(baseImg v1.Image) (types.MediaType, error) {
+	mt, err := baseImg.MediaType()
+	if err != nil {
+		return "", err
+	}
+
+	switch mt {
+	case types.OCIManifestSchema1:
+		return types.OCILayer, nil
+	case types.DockerManifestSchema2:
+		return types.DockerLayer, nil
+	default:
+		return "", fmt.Errorf("invalid base image media type '%s' expected one of '%s' or '%s'", mt, types.OCIManifestSchema1, types.DockerManifestSchema2)
+	}
+}
+
+func (b *ImageBuilder) assembleImage(baseImg v1.Image, opts BuildOptions, addenda ...mutate.Addendum) (v1.Image, error) {
+	img, err := mutate.Append(baseImg, addenda...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add layers to base image: %w", err)
+	}
+
+	cfg, err := img.ConfigFile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image config while building image: %w", err)
+	}
+
+	createTime := time.Now()
+
+	cfg = cfg.DeepCopy()
+
+	binaryPath := "/usr/local/bin/genmcp-server"
+	workingDir := "/app"
+	mcpFilePath := "/app/mcpfile.yaml"
+	if opts.Platform.OS == "windows" {
+		binaryPath = `C:\usr\local\bin\genmcp-server.exe`
+		workingDir = `C:\app`
+		mcpFilePath = `C:\app\mcpfile.yaml`
+	}
+
+	cfg.Config.Entrypoint = []string{binaryPath}
+	cfg.Config.WorkingDir = workingDir
+	cfg.Config.Env = append(cfg.Config.Env, "MCP_FILE_PATH="+mcpFilePath)
+	cfg.Config.User = "1001:1001"
+	cfg.Created = v1.Time{Time: createTime}
+
+	if cfg.Config.Labels == nil {
+		cfg.Config.Labels = make(map[string]string)
+	}
+
+	// add standard OCI labels
+	cfg.Config.Labels[ImageTitleLabel] = "genmcp-server"
+	cfg.Config.Labels[ImageDescriptionLabel] = "GenMCP Server Image"
+	cfg.Config.Labels[ImageCreatedLabel] = createTime.Format(time.RFC3339)
+
+	if opts.ImageTag != "" {
+		cfg.Config.Labels[ImageRefNameLabel] = opts.ImageTag
+
+		if tag := extractTagFromReference(opts.ImageTag); tag != "" {
+			cfg.Config.Labels[ImageVersionLabel] = tag
+		}
+	}
+
+	return mutate.ConfigFile(img, cfg)
+}
+
+// createBinaryLayer creates a tarball layer with the genmcp-server binary at /usr/local/bin/genmcp-server
+func (b *ImageBuilder) createBinaryLayer(
+	binaryData []byte,
+	fileInfo fs.FileInfo,
+	platform *v1.Platform,
+	layerMediaType types.MediaType,
+) (v1.Layer, error) {
+	fileName := "genmcp-server"
+	if platform.OS == "windows" {
+		fileName = "genmcp-server.exe"
+	}
+
+	layerData, err := createTarWithFile("/usr/local/bin", fileName, platform.OS, binaryData, fileInfo, 0777)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create layer for genmcp-server binary: %w", err)
+	}
+
+	return tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewBuffer(layerData.Bytes())), nil
+	}, tarball.WithCompressedCaching, tarball.WithMediaType(layerMediaType))
+}
+
+// createMCPFileLayer creates a tarball layer with the mcpfile.yaml at /app/mcpfile.yaml
+func (b *ImageBuilder) createMCPFileLayer(
+	mcpFileData []byte,
+	fileInfo fs.FileInfo,
+	platform *v1.Platform,
+	layerMediaType types.MediaType,
+) (v1.Layer, error) {
+	layerData, err := createTarWithFile("/app", "mcpfile.yaml", platform.OS, mcpFileData, fileInfo, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create layer for mcpfile.yaml: %w", err)
+	}
+
+	return tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewBuffer(layerData.Bytes())), nil
+	}, tarball.WithCompressedCaching, tarball.WithMediaType(layerMediaType))
+}
+
+func createTarWithFile(filepath, filename, os string, data []byte, fileInfo fs.FileInfo, mode int64) (*bytes.Buffer, error) {
+	buf := bytes.NewBuffer(nil)
+	tw := tar.NewWriter(buf)
+	defer func() { _ = tw.Close() }()
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     filepath,
+		Typeflag: tar.TypeDir,
+		Mode:     0555,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to write dir %s to tar: %w", filepath, err)
+	}
+
+	header := &tar.Header{
+		Name:       filepath + "/" + filename,
+		Size:       fileInfo.Size(),
+		Typeflag:   tar.TypeReg,
+		Mode:       mode,
+		PAXRecords: map[string]string{},
+	}
+
+	if os == "windows" {
+		// need to set magic value for the binary to be executable
+		header.PAXRecords["MSWINDOWS.rawsd"] = userOwnerAndGroupSID
+	}
+
+	if err := tw.WriteHeader(header); err != nil {
+		return nil, fmt.Errorf("failed to write header for file %s to tar: %w", filename, err)
+	}
+
+	if _, err := tw.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to write data for file %s to tar: %w", filename, err)
+	}
+
+	return buf, nil
+}
+
+func extractTagFromReference(reference string) string {
+	parts := strings.Split(reference, ":")
+	if len(parts) > 1 {
+		return parts[len(parts)-1]
+	}
+
+	return ""
+}
@@ -1,18 +1,30 @@
 package builder
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
 	"io/fs"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
-	"github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/fake"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/partial"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
 	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // Mock implementations for testing
@@ -49,8 +61,8 @@ type mockImageDownloader struct {
 	mock.Mock
 }
 
-func (m *mockImageDownloader) DownloadImage(ctx context.Context, baseImage string, platform *v1.Platform) (v1.Image, error) {
-	args := m.Called(ctx, baseImage, platform)
+func (m *mockImageDownloader) DownloadImage(ctx context.Context, baseImage string, platform *v1.Platform, progress chan<- v1.Update) (v1.Image, error) {
+	args := m.Called(ctx, baseImage, platform, progress)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -66,6 +78,11 @@ func (m *mockImageSaver) SaveImage(ctx context.Context, img v1.Image, ref string
 	return args.Error(0)
 }
 
+func (m *mockImageSaver) SaveImageIndex(ctx context.Context, idx v1.ImageIndex, ref string) error {
+	args := m.Called(ctx, idx, ref)
+	return args.Error(0)
+}
+
 type mockFileInfo struct {
 	name    string
 	size    int64
@@ -129,6 +146,40 @@ func newTestImage(mediaType types.MediaType) v1.Image {
 	return img
 }
 
+func testEncryptionRecipientPEM(t *testing.T) string {
+	t.Helper()
+	_, pubPEM := generateTestRSAKeyPair(t)
+	return pubPEM
+}
+
+// decodeProgressEvents parses JSON-lines progress output, returning every
+// non-aux event and whether a final aux event was present. The aux event is
+// reported separately since it carries a nondeterministic image digest.
+func decodeProgressEvents(t *testing.T, data []byte) ([]map[string]any, bool) {
+	t.Helper()
+
+	var events []map[string]any
+	auxSeen := false
+
+	for _, line := range bytes.Split(bytes.TrimSpace(data), []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+
+		var event map[string]any
+		require.NoError(t, json.Unmarshal(line, &event))
+
+		if _, ok := event["aux"]; ok {
+			auxSeen = true
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	return events, auxSeen
+}
+
 func TestImageBuilder_Build(t *testing.T) {
 	tt := []struct {
 		name           string
@@ -136,6 +187,12 @@ func TestImageBuilder_Build(t *testing.T) {
 		setupMocks     func(*mockFileSystem, *mockBinaryProvider, *mockImageDownloader)
 		expectedError  string
 		validateResult func(t *testing.T, img v1.Image)
+		// progressEvents, when set, is the exact sequence of "stream" and
+		// "status" events (in JSON-lines form) that Build must emit to
+		// BuildOptions.ProgressWriter, in order and excluding the final
+		// "aux" event, which is checked separately since it carries a
+		// nondeterministic digest.
+		progressEvents []map[string]any
 	}{
 		{
 			name: "successful build with default options",
@@ -146,7 +203,7 @@ func TestImageBuilder_Build(t *testing.T) {
 			setupMocks: func(mfs *mockFileSystem, mbp *mockBinaryProvider, mid *mockImageDownloader) {
 				// Mock base image download
 				baseImg := newTestImage(types.DockerManifestSchema2)
-				mid.On("DownloadImage", mock.Anything, DefaultBaseImage, &v1.Platform{OS: "linux", Architecture: "amd64"}).Return(baseImg, nil)
+				mid.On("DownloadImage", mock.Anything, DefaultBaseImage, &v1.Platform{OS: "linux", Architecture: "amd64"}, mock.Anything).Return(baseImg, nil)
 
 				// Mock binary extraction
 				binaryData := []byte("fake-binary-data")
@@ -162,6 +219,12 @@ func TestImageBuilder_Build(t *testing.T) {
 			validateResult: func(t *testing.T, img v1.Image) {
 				assert.NotNil(t, img, "should return a valid image")
 			},
+			progressEvents: []map[string]any{
+				{"stream": "pulling base image " + DefaultBaseImage + "\n"},
+				{"stream": "extracting genmcp-server binary for linux/amd64\n"},
+				{"stream": "adding mcpfile.yaml layer\n"},
+				{"stream": "updating image configuration\n"},
+			},
 		},
 		{
 			name: "build with custom platform",
@@ -173,7 +236,7 @@ func TestImageBuilder_Build(t *testing.T) {
 			},
 			setupMocks: func(mfs *mockFileSystem, mbp *mockBinaryProvider, mid *mockImageDownloader) {
 				baseImg := newTestImage(types.OCIManifestSchema1)
-				mid.On("DownloadImage", mock.Anything, "custom:base", &v1.Platform{OS: "windows", Architecture: "amd64"}).Return(baseImg, nil)
+				mid.On("DownloadImage", mock.Anything, "custom:base", &v1.Platform{OS: "windows", Architecture: "amd64"}, mock.Anything).Return(baseImg, nil)
 
 				binaryData := []byte("windows-binary-data")
 				binaryInfo := &mockFileInfo{name: "genmcp-server.exe", size: int64(len(binaryData))}
@@ -194,9 +257,12 @@ func TestImageBuilder_Build(t *testing.T) {
 				MCPFilePath: "/test/mcpfile.yaml",
 			},
 			setupMocks: func(mfs *mockFileSystem, mbp *mockBinaryProvider, mid *mockImageDownloader) {
-				mid.On("DownloadImage", mock.Anything, DefaultBaseImage, &v1.Platform{OS: "linux", Architecture: "amd64"}).Return(nil, errors.New("download failed"))
+				mid.On("DownloadImage", mock.Anything, DefaultBaseImage, &v1.Platform{OS: "linux", Architecture: "amd64"}, mock.Anything).Return(nil, errors.New("download failed"))
 			},
 			expectedError: "failed to download base image: download failed",
+			progressEvents: []map[string]any{
+				{"stream": "pulling base image " + DefaultBaseImage + "\n"},
+			},
 		},
 		{
 			name: "failure - binary extraction error",
@@ -205,7 +271,7 @@ func TestImageBuilder_Build(t *testing.T) {
 			},
 			setupMocks: func(mfs *mockFileSystem, mbp *mockBinaryProvider, mid *mockImageDownloader) {
 				baseImg := newTestImage(types.DockerManifestSchema2)
-				mid.On("DownloadImage", mock.Anything, DefaultBaseImage, &v1.Platform{OS: "linux", Architecture: "amd64"}).Return(baseImg, nil)
+				mid.On("DownloadImage", mock.Anything, DefaultBaseImage, &v1.Platform{OS: "linux", Architecture: "amd64"}, mock.Anything).Return(baseImg, nil)
 				mbp.On("ExtractServerBinary", &v1.Platform{OS: "linux", Architecture: "amd64"}).Return([]byte{}, nil, errors.New("binary not found"))
 			},
 			expectedError: "failed to extract server binary: binary not found",
@@ -217,7 +283,7 @@ func TestImageBuilder_Build(t *testing.T) {
 			},
 			setupMocks: func(mfs *mockFileSystem, mbp *mockBinaryProvider, mid *mockImageDownloader) {
 				baseImg := newTestImage(types.DockerManifestSchema2)
-				mid.On("DownloadImage", mock.Anything, DefaultBaseImage, &v1.Platform{OS: "linux", Architecture: "amd64"}).Return(baseImg, nil)
+				mid.On("DownloadImage", mock.Anything, DefaultBaseImage, &v1.Platform{OS: "linux", Architecture: "amd64"}, mock.Anything).Return(baseImg, nil)
 
 				binaryData := []byte("fake-binary-data")
 				binaryInfo := &mockFileInfo{name: "genmcp-server", size: int64(len(binaryData))}
@@ -234,7 +300,7 @@ func TestImageBuilder_Build(t *testing.T) {
 			},
 			setupMocks: func(mfs *mockFileSystem, mbp *mockBinaryProvider, mid *mockImageDownloader) {
 				baseImg := newTestImage(types.DockerManifestSchema2)
-				mid.On("DownloadImage", mock.Anything, DefaultBaseImage, &v1.Platform{OS: "linux", Architecture: "amd64"}).Return(baseImg, nil)
+				mid.On("DownloadImage", mock.Anything, DefaultBaseImage, &v1.Platform{OS: "linux", Architecture: "amd64"}, mock.Anything).Return(baseImg, nil)
 
 				binaryData := []byte("fake-binary-data")
 				binaryInfo := &mockFileInfo{name: "genmcp-server", size: int64(len(binaryData))}
@@ -253,7 +319,7 @@ func TestImageBuilder_Build(t *testing.T) {
 			},
 			setupMocks: func(mfs *mockFileSystem, mbp *mockBinaryProvider, mid *mockImageDownloader) {
 				baseImg := newTestImage("application/vnd.unsupported")
-				mid.On("DownloadImage", mock.Anything, DefaultBaseImage, &v1.Platform{OS: "linux", Architecture: "amd64"}).Return(baseImg, nil)
+				mid.On("DownloadImage", mock.Anything, DefaultBaseImage, &v1.Platform{OS: "linux", Architecture: "amd64"}, mock.Anything).Return(baseImg, nil)
 
 				binaryData := []byte("fake-binary-data")
 				binaryInfo := &mockFileInfo{name: "genmcp-server", size: int64(len(binaryData))}
@@ -266,6 +332,43 @@ func TestImageBuilder_Build(t *testing.T) {
 			},
 			expectedError: "failed to get media type for layers: invalid base image media type",
 		},
+		{
+			name: "successful build with mcpfile layer encryption",
+			buildOptions: BuildOptions{
+				MCPFilePath: "/test/mcpfile.yaml",
+				ImageTag:    "test:latest",
+				EncryptionConfig: EncryptionConfig{
+					Recipients: []string{testEncryptionRecipientPEM(t)},
+				},
+			},
+			setupMocks: func(mfs *mockFileSystem, mbp *mockBinaryProvider, mid *mockImageDownloader) {
+				baseImg := newTestImage(types.DockerManifestSchema2)
+				mid.On("DownloadImage", mock.Anything, DefaultBaseImage, &v1.Platform{OS: "linux", Architecture: "amd64"}, mock.Anything).Return(baseImg, nil)
+
+				binaryData := []byte("fake-binary-data")
+				binaryInfo := &mockFileInfo{name: "genmcp-server", size: int64(len(binaryData))}
+				mbp.On("ExtractServerBinary", &v1.Platform{OS: "linux", Architecture: "amd64"}).Return(binaryData, binaryInfo, nil)
+
+				mcpFileData := []byte("fake-mcp-file-data")
+				mcpFileInfo := &mockFileInfo{name: "mcpfile.yaml", size: int64(len(mcpFileData))}
+				mfs.On("Stat", "/test/mcpfile.yaml").Return(mcpFileInfo, nil)
+				mfs.On("ReadFile", "/test/mcpfile.yaml").Return(mcpFileData, nil)
+			},
+			validateResult: func(t *testing.T, img v1.Image) {
+				layers, err := img.Layers()
+				require.NoError(t, err)
+				require.Len(t, layers, 2)
+
+				mt, err := layers[1].MediaType()
+				require.NoError(t, err)
+				assert.Equal(t, string(types.DockerLayer)+"+encrypted", string(mt))
+
+				manifest, err := img.Manifest()
+				require.NoError(t, err)
+				assert.Contains(t, manifest.Layers[1].Annotations, EncryptionKeysAnnotation)
+				assert.Contains(t, manifest.Layers[1].Annotations, EncryptionPubOptsAnnotation)
+			},
+		},
 	}
 
 	for _, tc := range tt {
@@ -288,6 +391,11 @@ func TestImageBuilder_Build(t *testing.T) {
 				imageSaver:      mockIS,
 			}
 
+			var progress bytes.Buffer
+			if tc.progressEvents != nil {
+				tc.buildOptions.ProgressWriter = &progress
+			}
+
 			// Execute test
 			ctx := context.Background()
 			result, err := builder.Build(ctx, tc.buildOptions)
@@ -304,6 +412,12 @@ func TestImageBuilder_Build(t *testing.T) {
 				}
 			}
 
+			if tc.progressEvents != nil {
+				events, auxSeen := decodeProgressEvents(t, progress.Bytes())
+				assert.Equal(t, tc.progressEvents, events, "emitted progress events should match expected sequence")
+				assert.Equal(t, tc.expectedError == "", auxSeen, "the final aux event should be emitted iff the build succeeded")
+			}
+
 			// Verify all expectations were met
 			mockFS.AssertExpectations(t)
 			mockBP.AssertExpectations(t)
@@ -518,3 +632,473 @@ func TestExtractTagFromReference(t *testing.T) {
 	}
 }
 
+func TestImageBuilder_BuildMultiArch(t *testing.T) {
+	tt := []struct {
+		name           string
+		buildOptions   MultiArchBuildOptions
+		setupMocks     func(*mockFileSystem, *mockBinaryProvider, *mockImageDownloader)
+		expectedError  string
+		validateResult func(t *testing.T, idx v1.ImageIndex)
+	}{
+		{
+			name: "successful multi-arch build with default platforms",
+			buildOptions: MultiArchBuildOptions{
+				MCPFilePath: "/test/mcpfile.yaml",
+				ImageTag:    "test:latest",
+			},
+			setupMocks: func(mfs *mockFileSystem, mbp *mockBinaryProvider, mid *mockImageDownloader) {
+				baseImgAmd64 := newTestImage(types.DockerManifestSchema2)
+				mid.On("DownloadImage", mock.Anything, DefaultBaseImage, &v1.Platform{OS: "linux", Architecture: "amd64"}, mock.Anything).Return(baseImgAmd64, nil)
+
+				binaryDataAmd64 := []byte("fake-binary-amd64")
+				binaryInfoAmd64 := &mockFileInfo{name: "genmcp-server", size: int64(len(binaryDataAmd64))}
+				mbp.On("ExtractServerBinary", &v1.Platform{OS: "linux", Architecture: "amd64"}).Return(binaryDataAmd64, binaryInfoAmd64, nil)
+
+				baseImgArm64 := newTestImage(types.DockerManifestSchema2)
+				mid.On("DownloadImage", mock.Anything, DefaultBaseImage, &v1.Platform{OS: "linux", Architecture: "arm64"}, mock.Anything).Return(baseImgArm64, nil)
+
+				binaryDataArm64 := []byte("fake-binary-arm64")
+				binaryInfoArm64 := &mockFileInfo{name: "genmcp-server", size: int64(len(binaryDataArm64))}
+				mbp.On("ExtractServerBinary", &v1.Platform{OS: "linux", Architecture: "arm64"}).Return(binaryDataArm64, binaryInfoArm64, nil)
+
+				mcpFileData := []byte("fake-mcp-file-data")
+				mcpFileInfo := &mockFileInfo{name: "mcpfile.yaml", size: int64(len(mcpFileData))}
+				mfs.On("Stat", "/test/mcpfile.yaml").Return(mcpFileInfo, nil).Times(2)
+				mfs.On("ReadFile", "/test/mcpfile.yaml").Return(mcpFileData, nil).Times(2)
+			},
+			validateResult: func(t *testing.T, idx v1.ImageIndex) {
+				assert.NotNil(t, idx, "should return a valid image index")
+
+				manifest, err := idx.IndexManifest()
+				assert.NoError(t, err, "should be able to get index manifest")
+				assert.Len(t, manifest.Manifests, 2, "should have one descriptor per platform")
+				assert.Equal(t, types.DockerManifestList, manifest.MediaType, "docker base images should produce a docker manifest list")
+
+				platforms := make(map[string]bool)
+				for _, desc := range manifest.Manifests {
+					if desc.Platform != nil {
+						platforms[desc.Platform.OS+"/"+desc.Platform.Architecture] = true
+					}
+				}
+				assert.True(t, platforms["linux/amd64"], "should have linux/amd64")
+				assert.True(t, platforms["linux/arm64"], "should have linux/arm64")
+			},
+		},
+		{
+			name: "successful multi-arch build with custom platforms and OCI base images",
+			buildOptions: MultiArchBuildOptions{
+				Platforms: []*v1.Platform{
+					{OS: "linux", Architecture: "amd64"},
+					{OS: "windows", Architecture: "amd64"},
+				},
+				BaseImage:   "custom:base",
+				MCPFilePath: "/custom/mcpfile.yaml",
+				ImageTag:    "custom:tag",
+			},
+			setupMocks: func(mfs *mockFileSystem, mbp *mockBinaryProvider, mid *mockImageDownloader) {
+				baseImgLinux := newTestImage(types.OCIManifestSchema1)
+				mid.On("DownloadImage", mock.Anything, "custom:base", &v1.Platform{OS: "linux", Architecture: "amd64"}, mock.Anything).Return(baseImgLinux, nil)
+
+				binaryDataLinux := []byte("linux-binary")
+				binaryInfoLinux := &mockFileInfo{name: "genmcp-server", size: int64(len(binaryDataLinux))}
+				mbp.On("ExtractServerBinary", &v1.Platform{OS: "linux", Architecture: "amd64"}).Return(binaryDataLinux, binaryInfoLinux, nil)
+
+				baseImgWindows := newTestImage(types.OCIManifestSchema1)
+				mid.On("DownloadImage", mock.Anything, "custom:base", &v1.Platform{OS: "windows", Architecture: "amd64"}, mock.Anything).Return(baseImgWindows, nil)
+
+				binaryDataWindows := []byte("windows-binary")
+				binaryInfoWindows := &mockFileInfo{name: "genmcp-server.exe", size: int64(len(binaryDataWindows))}
+				mbp.On("ExtractServerBinary", &v1.Platform{OS: "windows", Architecture: "amd64"}).Return(binaryDataWindows, binaryInfoWindows, nil)
+
+				mcpFileData := []byte("custom-mcp-data")
+				mcpFileInfo := &mockFileInfo{name: "mcpfile.yaml", size: int64(len(mcpFileData))}
+				mfs.On("Stat", "/custom/mcpfile.yaml").Return(mcpFileInfo, nil).Times(2)
+				mfs.On("ReadFile", "/custom/mcpfile.yaml").Return(mcpFileData, nil).Times(2)
+			},
+			validateResult: func(t *testing.T, idx v1.ImageIndex) {
+				assert.NotNil(t, idx, "should return a valid image index")
+
+				manifest, err := idx.IndexManifest()
+				assert.NoError(t, err, "should be able to get index manifest")
+				assert.Len(t, manifest.Manifests, 2, "should have one descriptor per platform")
+				assert.Equal(t, types.OCIImageIndex, manifest.MediaType, "all-OCI base images should produce an OCI image index")
+			},
+		},
+		{
+			name: "failure - one platform build fails",
+			buildOptions: MultiArchBuildOptions{
+				Platforms: []*v1.Platform{
+					{OS: "linux", Architecture: "amd64"},
+					{OS: "linux", Architecture: "arm64"},
+				},
+				MCPFilePath: "/test/mcpfile.yaml",
+			},
+			setupMocks: func(mfs *mockFileSystem, mbp *mockBinaryProvider, mid *mockImageDownloader) {
+				baseImgAmd64 := newTestImage(types.DockerManifestSchema2)
+				mid.On("DownloadImage", mock.Anything, DefaultBaseImage, &v1.Platform{OS: "linux", Architecture: "amd64"}, mock.Anything).Return(baseImgAmd64, nil)
+
+				binaryDataAmd64 := []byte("fake-binary-amd64")
+				binaryInfoAmd64 := &mockFileInfo{name: "genmcp-server", size: int64(len(binaryDataAmd64))}
+				mbp.On("ExtractServerBinary", &v1.Platform{OS: "linux", Architecture: "amd64"}).Return(binaryDataAmd64, binaryInfoAmd64, nil)
+
+				mcpFileData := []byte("fake-mcp-file-data")
+				mcpFileInfo := &mockFileInfo{name: "mcpfile.yaml", size: int64(len(mcpFileData))}
+				mfs.On("Stat", "/test/mcpfile.yaml").Return(mcpFileInfo, nil).Maybe()
+				mfs.On("ReadFile", "/test/mcpfile.yaml").Return(mcpFileData, nil).Maybe()
+
+				mid.On("DownloadImage", mock.Anything, DefaultBaseImage, &v1.Platform{OS: "linux", Architecture: "arm64"}, mock.Anything).Return(newTestImage(types.DockerManifestSchema2), nil)
+				mbp.On("ExtractServerBinary", &v1.Platform{OS: "linux", Architecture: "arm64"}).Return([]byte{}, nil, errors.New("arm64 binary not found"))
+			},
+			expectedError: "failed to build image for platform linux/arm64",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockFS := &mockFileSystem{}
+			mockBP := &mockBinaryProvider{}
+			mockID := &mockImageDownloader{}
+			mockIS := &mockImageSaver{}
+
+			tc.setupMocks(mockFS, mockBP, mockID)
+
+			builder := &ImageBuilder{
+				fs:              mockFS,
+				binaryProvider:  mockBP,
+				imageDownloader: mockID,
+				imageSaver:      mockIS,
+			}
+
+			ctx := context.Background()
+			result, err := builder.BuildMultiArch(ctx, tc.buildOptions)
+
+			if tc.expectedError != "" {
+				assert.Error(t, err, "should return an error")
+				assert.Contains(t, err.Error(), tc.expectedError, "error message should contain expected text")
+				assert.Nil(t, result, "should not return a result on error")
+			} else {
+				assert.NoError(t, err, "should not return an error")
+				if tc.validateResult != nil {
+					tc.validateResult(t, result)
+				}
+			}
+
+			mockFS.AssertExpectations(t)
+			mockBP.AssertExpectations(t)
+			mockID.AssertExpectations(t)
+		})
+	}
+}
+
+func TestImageBuilder_BuildMultiArch_BuildsPlatformsConcurrently(t *testing.T) {
+	platforms := []*v1.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64"},
+		{OS: "linux", Architecture: "386"},
+	}
+	const perPlatformDelay = 100 * time.Millisecond
+
+	mockFS := &mockFileSystem{}
+	mockBP := &mockBinaryProvider{}
+	mockID := &mockImageDownloader{}
+	mockIS := &mockImageSaver{}
+
+	for _, platform := range platforms {
+		mockID.On("DownloadImage", mock.Anything, DefaultBaseImage, platform, mock.Anything).
+			Run(func(mock.Arguments) { time.Sleep(perPlatformDelay) }).
+			Return(newTestImage(types.DockerManifestSchema2), nil)
+
+		binaryData := []byte("fake-binary-" + platform.Architecture)
+		binaryInfo := &mockFileInfo{name: "genmcp-server", size: int64(len(binaryData))}
+		mockBP.On("ExtractServerBinary", platform).Return(binaryData, binaryInfo, nil)
+	}
+
+	mcpFileData := []byte("fake-mcp-file-data")
+	mcpFileInfo := &mockFileInfo{name: "mcpfile.yaml", size: int64(len(mcpFileData))}
+	mockFS.On("Stat", "/test/mcpfile.yaml").Return(mcpFileInfo, nil)
+	mockFS.On("ReadFile", "/test/mcpfile.yaml").Return(mcpFileData, nil)
+
+	builder := &ImageBuilder{
+		fs:              mockFS,
+		binaryProvider:  mockBP,
+		imageDownloader: mockID,
+		imageSaver:      mockIS,
+	}
+
+	start := time.Now()
+	idx, err := builder.BuildMultiArch(context.Background(), MultiArchBuildOptions{
+		Platforms:   platforms,
+		MCPFilePath: "/test/mcpfile.yaml",
+	})
+	elapsed := time.Since(start)
+
+	require.NoError(t, err)
+	require.NotNil(t, idx)
+
+	// If the platforms were built sequentially this would take at least
+	// len(platforms)*perPlatformDelay; building them concurrently should
+	// take roughly one perPlatformDelay plus scheduling overhead.
+	assert.Less(t, elapsed, time.Duration(len(platforms))*perPlatformDelay,
+		"platforms should build concurrently, not sequentially")
+}
+
+// TestImageBuilder_BuildMultiArch_ConcurrentProgressWriterIsSafe builds
+// multiple platforms concurrently with a shared, non-thread-safe
+// ProgressWriter (*bytes.Buffer), and asserts that every line written to it
+// is valid, complete JSON — i.e. that concurrent platforms' progress events
+// never interleave or race on the underlying writer. Run with -race to catch
+// the underlying data race directly.
+func TestImageBuilder_BuildMultiArch_ConcurrentProgressWriterIsSafe(t *testing.T) {
+	platforms := []*v1.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64"},
+		{OS: "linux", Architecture: "386"},
+	}
+
+	mockFS := &mockFileSystem{}
+	mockBP := &mockBinaryProvider{}
+	mockID := &mockImageDownloader{}
+	mockIS := &mockImageSaver{}
+
+	for _, platform := range platforms {
+		mockID.On("DownloadImage", mock.Anything, DefaultBaseImage, platform, mock.Anything).
+			Run(func(mock.Arguments) { time.Sleep(10 * time.Millisecond) }).
+			Return(newTestImage(types.DockerManifestSchema2), nil)
+
+		binaryData := []byte("fake-binary-" + platform.Architecture)
+		binaryInfo := &mockFileInfo{name: "genmcp-server", size: int64(len(binaryData))}
+		mockBP.On("ExtractServerBinary", platform).Return(binaryData, binaryInfo, nil)
+	}
+
+	mcpFileData := []byte("fake-mcp-file-data")
+	mcpFileInfo := &mockFileInfo{name: "mcpfile.yaml", size: int64(len(mcpFileData))}
+	mockFS.On("Stat", "/test/mcpfile.yaml").Return(mcpFileInfo, nil)
+	mockFS.On("ReadFile", "/test/mcpfile.yaml").Return(mcpFileData, nil)
+
+	builder := &ImageBuilder{
+		fs:              mockFS,
+		binaryProvider:  mockBP,
+		imageDownloader: mockID,
+		imageSaver:      mockIS,
+	}
+
+	// A plain *bytes.Buffer is not safe for concurrent writes; BuildMultiArch
+	// must serialize platforms' progress writes itself, by the time it
+	// returns, for this to be race-free.
+	var buf bytes.Buffer
+	idx, err := builder.BuildMultiArch(context.Background(), MultiArchBuildOptions{
+		Platforms:      platforms,
+		MCPFilePath:    "/test/mcpfile.yaml",
+		ProgressWriter: &buf,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, idx)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.NotEmpty(t, lines)
+	for _, line := range lines {
+		var event map[string]any
+		assert.NoErrorf(t, json.Unmarshal(line, &event), "every line must be valid, non-interleaved JSON: %q", line)
+	}
+}
+
+func TestDefaultImageDownloader_DownloadImage_UsesConfiguredKeychain(t *testing.T) {
+	srv := httptest.NewServer(registry.New())
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	ref, err := name.ParseReference(u.Host + "/test/image:latest")
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, newTestImage(types.OCIManifestSchema1)))
+
+	keychain := &fakeKeychain{}
+	downloader := &DefaultImageDownloader{Keychain: keychain}
+
+	_, err = downloader.DownloadImage(context.Background(), ref.String(), &v1.Platform{OS: "linux", Architecture: "amd64"}, nil)
+	require.NoError(t, err)
+
+	assert.True(t, keychain.resolved, "DownloadImage should resolve credentials through the configured Keychain")
+}
+
+func TestKeySigner_SignImage_UsesConfiguredKeychain(t *testing.T) {
+	srv := httptest.NewServer(registry.New())
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	_, keyPath := generateTestKeyFile(t)
+	ref := u.Host + "/test/image:latest"
+	digest := v1.Hash{Algorithm: "sha256", Hex: "deadbeef00000000000000000000000000000000000000000000000000000000"[:64]}
+
+	keychain := &fakeKeychain{}
+	signer := &KeySigner{Options: SigningOptions{KeyPath: keyPath}, Keychain: keychain}
+
+	require.NoError(t, signer.SignImage(context.Background(), ref, digest))
+	assert.True(t, keychain.resolved, "SignImage should resolve credentials through the configured Keychain")
+}
+
+func TestDefaultImageDownloader_DownloadImage_ReportsRealProgress(t *testing.T) {
+	srv := httptest.NewServer(registry.New())
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	layerData := bytes.Repeat([]byte("x"), 4096)
+	img, err := mutate.Append(empty.Image, mutate.Addendum{Layer: static.NewLayer(layerData, types.OCILayer)})
+	require.NoError(t, err)
+
+	ref, err := name.ParseReference(u.Host + "/test/image:latest")
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+
+	downloader := &DefaultImageDownloader{}
+	updates := make(chan v1.Update, 50)
+
+	pulled, err := downloader.DownloadImage(context.Background(), ref.String(), &v1.Platform{OS: "linux", Architecture: "amd64"}, updates)
+	close(updates)
+	require.NoError(t, err)
+
+	var events []v1.Update
+	for u := range updates {
+		events = append(events, u)
+	}
+
+	require.NotEmpty(t, events, "DownloadImage should report at least one progress update for a real pull")
+	last := events[len(events)-1]
+	assert.EqualValues(t, len(layerData), last.Complete, "final progress update should reflect the full layer size")
+	assert.EqualValues(t, len(layerData), last.Total)
+
+	// The returned image must still be fully usable (e.g. for re-pushing)
+	// after DownloadImage has already drained its layers for progress.
+	layers, err := pulled.Layers()
+	require.NoError(t, err)
+	require.Len(t, layers, 1)
+
+	rc, err := layers[0].Compressed()
+	require.NoError(t, err)
+	defer func() { _ = rc.Close() }()
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, layerData, data)
+}
+
+func TestImageBuilder_SaveIndex(t *testing.T) {
+	tt := []struct {
+		name          string
+		imageRef      string
+		setupMocks    func(*mockImageSaver)
+		expectedError string
+	}{
+		{
+			name:     "successful push",
+			imageRef: "docker.io/test/image:latest",
+			setupMocks: func(mis *mockImageSaver) {
+				mis.On("SaveImageIndex", mock.Anything, mock.Anything, "docker.io/test/image:latest").Return(nil)
+			},
+		},
+		{
+			name:     "push failure",
+			imageRef: "registry.example.com/test/image:v1.0.0",
+			setupMocks: func(mis *mockImageSaver) {
+				mis.On("SaveImageIndex", mock.Anything, mock.Anything, "registry.example.com/test/image:v1.0.0").Return(errors.New("push failed"))
+			},
+			expectedError: "push failed",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockIS := &mockImageSaver{}
+			tc.setupMocks(mockIS)
+
+			builder := &ImageBuilder{
+				imageSaver: mockIS,
+			}
+
+			idx := mutate.IndexMediaType(empty.Index, types.DockerManifestList)
+
+			ctx := context.Background()
+			err := builder.SaveIndex(ctx, idx, tc.imageRef)
+
+			if tc.expectedError != "" {
+				assert.Error(t, err, "should return an error")
+				assert.Contains(t, err.Error(), tc.expectedError, "error message should contain expected text")
+			} else {
+				assert.NoError(t, err, "should not return an error")
+			}
+
+			mockIS.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMultiArchBuildOptions_SetDefaults(t *testing.T) {
+	tt := []struct {
+		name           string
+		input          MultiArchBuildOptions
+		expectedOutput MultiArchBuildOptions
+	}{
+		{
+			name:  "empty options should get defaults",
+			input: MultiArchBuildOptions{},
+			expectedOutput: MultiArchBuildOptions{
+				BaseImage: DefaultBaseImage,
+				Platforms: []*v1.Platform{
+					{OS: "linux", Architecture: "amd64"},
+					{OS: "linux", Architecture: "arm64"},
+				},
+			},
+		},
+		{
+			name: "partial options should only set missing defaults",
+			input: MultiArchBuildOptions{
+				BaseImage: "custom:image",
+			},
+			expectedOutput: MultiArchBuildOptions{
+				BaseImage: "custom:image",
+				Platforms: []*v1.Platform{
+					{OS: "linux", Architecture: "amd64"},
+					{OS: "linux", Architecture: "arm64"},
+				},
+			},
+		},
+		{
+			name: "full options should remain unchanged",
+			input: MultiArchBuildOptions{
+				Platforms: []*v1.Platform{
+					{OS: "windows", Architecture: "amd64"},
+					{OS: "linux", Architecture: "arm64"},
+				},
+				BaseImage:   "custom:base",
+				MCPFilePath: "/custom/path/mcpfile.yaml",
+				ImageTag:    "custom:tag",
+			},
+			expectedOutput: MultiArchBuildOptions{
+				Platforms: []*v1.Platform{
+					{OS: "windows", Architecture: "amd64"},
+					{OS: "linux", Architecture: "arm64"},
+				},
+				BaseImage:   "custom:base",
+				MCPFilePath: "/custom/path/mcpfile.yaml",
+				ImageTag:    "custom:tag",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.input.SetDefaults()
+			assert.Equal(t, tc.expectedOutput, tc.input, "SetDefaults should produce expected output")
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package builder
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// Annotation keys the OCI image encryption spec stores a layer's wrapped
+// data-encryption keys and public-key wrapping options under.
+const (
+	EncryptionKeysAnnotation    = "org.opencontainers.image.enc.keys.jwe"
+	EncryptionPubOptsAnnotation = "org.opencontainers.image.enc.pubopts"
+)
+
+// encryptedMediaTypeSuffix is appended to a layer's media type once it's
+// been encrypted, per the OCI image encryption spec.
+const encryptedMediaTypeSuffix = "+encrypted"
+
+// EncryptionConfig configures ImageBuilder's optional layer encryption. When
+// Recipients is non-empty, the MCP file layer (which may contain sensitive
+// config) is encrypted so only holders of one of the recipients' private
+// keys can read it.
+type EncryptionConfig struct {
+	// Recipients are PEM-encoded RSA public keys of the parties who should
+	// be able to decrypt the layers.
+	Recipients []string
+	// EncryptBinary also encrypts the genmcp-server binary layer. By
+	// default only the MCP file layer is encrypted.
+	EncryptBinary bool
+}
+
+// Encrypter encrypts an image layer per the OCI image encryption spec. It
+// returns a new layer with a "+encrypted" media type suffix, plus the
+// annotations that must be attached to that layer's descriptor so a
+// decrypter can recover the data-encryption key.
+type Encrypter interface {
+	EncryptLayer(layer v1.Layer, recipients []string) (v1.Layer, map[string]string, error)
+}
+
+// Decrypter reverses an Encrypter's output. genmcp never needs to decrypt a
+// layer it built, so this exists only to let tests validate the round trip.
+type Decrypter interface {
+	DecryptLayer(layer v1.Layer, annotations map[string]string, privateKeyPEM []byte) ([]byte, error)
+}
+
+// jweDocument is the JWE-shaped document stored (base64-encoded) in a
+// layer's EncryptionKeysAnnotation: one RSA-OAEP-wrapped copy of the
+// data-encryption key per recipient.
+type jweDocument struct {
+	Recipients []jweRecipient `json:"recipients"`
+}
+
+type jweRecipient struct {
+	Header       jweHeader `json:"header"`
+	EncryptedKey string    `json:"encrypted_key"`
+}
+
+type jweHeader struct {
+	Alg string `json:"alg"`
+}
+
+// pubOptsDocument is the document stored (base64-encoded) in a layer's
+// EncryptionPubOptsAnnotation, describing the symmetric cipher used so a
+// decrypter knows how to unwrap the layer once it has the DEK.
+type pubOptsDocument struct {
+	Cipher   string `json:"cipher"`
+	HashType string `json:"hashtype"`
+}
+
+// AESEncrypter is the default Encrypter. It generates a fresh AES-256-GCM
+// data-encryption key per layer and wraps it for each recipient with
+// RSA-OAEP-256.
+type AESEncrypter struct{}
+
+func (e *AESEncrypter) EncryptLayer(layer v1.Layer, recipients []string) (v1.Layer, map[string]string, error) {
+	if len(recipients) == 0 {
+		return nil, nil, errors.New("no recipients configured for layer encryption")
+	}
+
+	mt, err := layer.MediaType()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get layer media type: %w", err)
+	}
+
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read layer contents: %w", err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	plaintext, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read layer contents: %w", err)
+	}
+
+	dek := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, dek); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate data encryption key: %w", err)
+	}
+
+	gcm, err := newAESGCM(dek)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	ciphertext := gcm.Seal(nonce, nonce, plaintext, nil)
+
+	wrappedKeys := make([]jweRecipient, 0, len(recipients))
+	for _, recipientPEM := range recipients {
+		pub, err := parseRSAPublicKey(recipientPEM)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse recipient public key: %w", err)
+		}
+
+		wrapped, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, dek, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to wrap data encryption key: %w", err)
+		}
+
+		wrappedKeys = append(wrappedKeys, jweRecipient{
+			Header:       jweHeader{Alg: "RSA-OAEP-256"},
+			EncryptedKey: base64.RawURLEncoding.EncodeToString(wrapped),
+		})
+	}
+
+	keysDoc, err := json.Marshal(jweDocument{Recipients: wrappedKeys})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal wrapped keys: %w", err)
+	}
+
+	pubOpts, err := json.Marshal(pubOptsDocument{Cipher: "AES256GCM", HashType: "sha256"})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal public key options: %w", err)
+	}
+
+	annotations := map[string]string{
+		EncryptionKeysAnnotation:    base64.StdEncoding.EncodeToString(keysDoc),
+		EncryptionPubOptsAnnotation: base64.StdEncoding.EncodeToString(pubOpts),
+	}
+
+	encLayer := static.NewLayer(ciphertext, types.MediaType(string(mt)+encryptedMediaTypeSuffix))
+
+	return encLayer, annotations, nil
+}
+
+// AESDecrypter reverses AESEncrypter. It's used only by tests.
+type AESDecrypter struct{}
+
+func (d *AESDecrypter) DecryptLayer(layer v1.Layer, annotations map[string]string, privateKeyPEM []byte) ([]byte, error) {
+	mt, err := layer.MediaType()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get layer media type: %w", err)
+	}
+
+	if !isEncryptedMediaType(mt) {
+		return nil, fmt.Errorf("layer media type %s is not encrypted", mt)
+	}
+
+	priv, err := parseRSAPrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse recipient private key: %w", err)
+	}
+
+	keysDocB64, ok := annotations[EncryptionKeysAnnotation]
+	if !ok {
+		return nil, fmt.Errorf("layer is missing the %s annotation", EncryptionKeysAnnotation)
+	}
+
+	keysDocBytes, err := base64.StdEncoding.DecodeString(keysDocB64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s annotation: %w", EncryptionKeysAnnotation, err)
+	}
+
+	var doc jweDocument
+	if err := json.Unmarshal(keysDocBytes, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation: %w", EncryptionKeysAnnotation, err)
+	}
+
+	dek, err := unwrapDEK(priv, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newAESGCM(dek)
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read layer contents: %w", err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	ciphertext, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read layer contents: %w", err)
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, errors.New("encrypted layer is shorter than the AES-GCM nonce")
+	}
+
+	nonce, ct := ciphertext[:nonceSize], ciphertext[nonceSize:]
+
+	plaintext, err := gcm.Open(nil, nonce, ct, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt layer: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+func unwrapDEK(priv *rsa.PrivateKey, doc jweDocument) ([]byte, error) {
+	var lastErr error
+	for _, recipient := range doc.Recipients {
+		wrapped, err := base64.RawURLEncoding.DecodeString(recipient.EncryptedKey)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		dek, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, priv, wrapped, nil)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		return dek, nil
+	}
+
+	return nil, fmt.Errorf("failed to unwrap data encryption key for any recipient: %w", lastErr)
+}
+
+func newAESGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize AES cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize AES-GCM: %w", err)
+	}
+
+	return gcm, nil
+}
+
+func isEncryptedMediaType(mt types.MediaType) bool {
+	s := string(mt)
+	return len(s) > len(encryptedMediaTypeSuffix) && s[len(s)-len(encryptedMediaTypeSuffix):] == encryptedMediaTypeSuffix
+}
+
+func parseRSAPublicKey(keyPEM string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, errors.New("no PEM data found in recipient public key")
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("recipient public key is not an RSA key")
+	}
+
+	return rsaPub, nil
+}
+
+func parseRSAPrivateKey(keyPEM []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("no PEM data found in private key")
+	}
+
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
@@ -0,0 +1,112 @@
+package builder
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateTestRSAKeyPair(t *testing.T) (*rsa.PrivateKey, string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	pubPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}))
+
+	return key, pubPEM
+}
+
+func TestAESEncrypter_EncryptLayer_RoundTrip(t *testing.T) {
+	key, pubPEM := generateTestRSAKeyPair(t)
+	plaintext := []byte("servers:\n  - name: secret-mcp-server\n")
+	layer := static.NewLayer(plaintext, types.OCILayer)
+
+	encrypter := &AESEncrypter{}
+	encLayer, annotations, err := encrypter.EncryptLayer(layer, []string{pubPEM})
+	require.NoError(t, err)
+
+	mt, err := encLayer.MediaType()
+	require.NoError(t, err)
+	assert.Equal(t, string(types.OCILayer)+"+encrypted", string(mt))
+	assert.Contains(t, annotations, EncryptionKeysAnnotation)
+	assert.Contains(t, annotations, EncryptionPubOptsAnnotation)
+
+	privDER := x509.MarshalPKCS1PrivateKey(key)
+	privPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: privDER})
+
+	decrypter := &AESDecrypter{}
+	decrypted, err := decrypter.DecryptLayer(encLayer, annotations, privPEM)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+}
+
+func TestAESEncrypter_EncryptLayer_MultipleRecipients(t *testing.T) {
+	key1, pubPEM1 := generateTestRSAKeyPair(t)
+	_, pubPEM2 := generateTestRSAKeyPair(t)
+	plaintext := []byte("mcpfile contents")
+	layer := static.NewLayer(plaintext, types.DockerLayer)
+
+	encrypter := &AESEncrypter{}
+	encLayer, annotations, err := encrypter.EncryptLayer(layer, []string{pubPEM1, pubPEM2})
+	require.NoError(t, err)
+
+	privDER := x509.MarshalPKCS1PrivateKey(key1)
+	privPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: privDER})
+
+	decrypter := &AESDecrypter{}
+	decrypted, err := decrypter.DecryptLayer(encLayer, annotations, privPEM)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+}
+
+func TestAESEncrypter_EncryptLayer_NoRecipients(t *testing.T) {
+	layer := static.NewLayer([]byte("data"), types.OCILayer)
+
+	encrypter := &AESEncrypter{}
+	_, _, err := encrypter.EncryptLayer(layer, nil)
+	assert.EqualError(t, err, "no recipients configured for layer encryption")
+}
+
+func TestAESDecrypter_DecryptLayer_WrongKey(t *testing.T) {
+	_, pubPEM := generateTestRSAKeyPair(t)
+	otherKey, _ := generateTestRSAKeyPair(t)
+	layer := static.NewLayer([]byte("data"), types.OCILayer)
+
+	encrypter := &AESEncrypter{}
+	encLayer, annotations, err := encrypter.EncryptLayer(layer, []string{pubPEM})
+	require.NoError(t, err)
+
+	privDER := x509.MarshalPKCS1PrivateKey(otherKey)
+	privPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: privDER})
+
+	decrypter := &AESDecrypter{}
+	_, err = decrypter.DecryptLayer(encLayer, annotations, privPEM)
+	assert.Error(t, err)
+}
+
+func TestAESDecrypter_DecryptLayer_NotEncrypted(t *testing.T) {
+	_, pubPEM := generateTestRSAKeyPair(t)
+	encrypter := &AESEncrypter{}
+	_, annotations, err := encrypter.EncryptLayer(static.NewLayer([]byte("data"), types.OCILayer), []string{pubPEM})
+	require.NoError(t, err)
+
+	key, _ := generateTestRSAKeyPair(t)
+	privDER := x509.MarshalPKCS1PrivateKey(key)
+	privPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: privDER})
+
+	plainLayer := static.NewLayer([]byte("data"), types.OCILayer)
+	decrypter := &AESDecrypter{}
+	_, err = decrypter.DecryptLayer(plainLayer, annotations, privPEM)
+	assert.ErrorContains(t, err, "is not encrypted")
+}
@@ -0,0 +1,264 @@
+package builder
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+)
+
+// Keychain resolves registry credentials the way the Docker and Podman CLIs
+// do: first from the Docker config file (including credsStore/credHelpers
+// helper binaries), then from the Podman auth file, then from
+// DOCKER_USERNAME/DOCKER_PASSWORD, and finally anonymous.
+type Keychain struct{}
+
+var _ authn.Keychain = (*Keychain)(nil)
+
+// DefaultKeychain is the Keychain ImageSaver implementations use unless a
+// per-call BuildOptions.RegistryAuth override is set.
+var DefaultKeychain = &Keychain{}
+
+func (k *Keychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	registry := target.RegistryStr()
+
+	if auth, ok, err := k.resolveDockerConfig(registry); err != nil {
+		return nil, err
+	} else if ok {
+		return auth, nil
+	}
+
+	if auth, ok, err := k.resolvePodmanAuth(registry); err != nil {
+		return nil, err
+	} else if ok {
+		return auth, nil
+	}
+
+	if username, password, ok := k.resolveEnv(); ok {
+		return &authn.Basic{Username: username, Password: password}, nil
+	}
+
+	return authn.Anonymous, nil
+}
+
+// dockerConfigFile mirrors the subset of ~/.docker/config.json that
+// credential resolution needs.
+type dockerConfigFile struct {
+	Auths       map[string]dockerConfigAuth `json:"auths"`
+	CredsStore  string                      `json:"credsStore"`
+	CredHelpers map[string]string           `json:"credHelpers"`
+}
+
+type dockerConfigAuth struct {
+	Auth string `json:"auth"`
+}
+
+// credentialHelperOutput is the JSON a docker-credential-* helper writes to
+// stdout in response to a "get" request.
+type credentialHelperOutput struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+func (k *Keychain) resolveDockerConfig(registry string) (authn.Authenticator, bool, error) {
+	path := dockerConfigPath()
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var cfg dockerConfigFile
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, false, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if helper, ok := cfg.CredHelpers[registry]; ok {
+		return k.resolveCredentialHelper(helper, registry)
+	}
+
+	if entry, ok := cfg.Auths[registry]; ok && entry.Auth != "" {
+		username, password, err := decodeBasicAuth(entry.Auth)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to decode auth for %s in %s: %w", registry, path, err)
+		}
+		return &authn.Basic{Username: username, Password: password}, true, nil
+	}
+
+	if cfg.CredsStore != "" {
+		return k.resolveCredentialHelper(cfg.CredsStore, registry)
+	}
+
+	return nil, false, nil
+}
+
+// resolveCredentialHelper runs docker-credential-<helper> get, following the
+// protocol documented at
+// https://docs.docker.com/reference/cli/docker/login/#credential-helpers:
+// the registry hostname is written to the helper's stdin and a JSON document
+// with the resolved credentials is read back from stdout.
+func (k *Keychain) resolveCredentialHelper(helper, registry string) (authn.Authenticator, bool, error) {
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(registry)
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+
+	if err := cmd.Run(); err != nil {
+		return nil, false, fmt.Errorf("docker-credential-%s get failed: %w", helper, err)
+	}
+
+	var out credentialHelperOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return nil, false, fmt.Errorf("failed to parse docker-credential-%s output: %w", helper, err)
+	}
+
+	if out.Username == "<token>" {
+		return authn.FromConfig(authn.AuthConfig{IdentityToken: out.Secret}), true, nil
+	}
+
+	return &authn.Basic{Username: out.Username, Password: out.Secret}, true, nil
+}
+
+// podmanAuthFile mirrors the subset of
+// $XDG_RUNTIME_DIR/containers/auth.json that credential resolution needs.
+type podmanAuthFile struct {
+	Auths map[string]dockerConfigAuth `json:"auths"`
+}
+
+func (k *Keychain) resolvePodmanAuth(registry string) (authn.Authenticator, bool, error) {
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		return nil, false, nil
+	}
+
+	path := filepath.Join(runtimeDir, "containers", "auth.json")
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var cfg podmanAuthFile
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, false, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	entry, ok := cfg.Auths[registry]
+	if !ok || entry.Auth == "" {
+		return nil, false, nil
+	}
+
+	username, password, err := decodeBasicAuth(entry.Auth)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to decode auth for %s in %s: %w", registry, path, err)
+	}
+
+	return &authn.Basic{Username: username, Password: password}, true, nil
+}
+
+func (k *Keychain) resolveEnv() (string, string, bool) {
+	username := os.Getenv("DOCKER_USERNAME")
+	password := os.Getenv("DOCKER_PASSWORD")
+	if username == "" || password == "" {
+		return "", "", false
+	}
+
+	return username, password, true
+}
+
+func dockerConfigPath() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".docker", "config.json")
+	}
+
+	return filepath.Join(home, ".docker", "config.json")
+}
+
+func decodeBasicAuth(auth string) (string, string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(auth)
+	if err != nil {
+		return "", "", err
+	}
+
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return "", "", fmt.Errorf("auth value is not formatted as base64(username:password)")
+	}
+
+	return username, password, nil
+}
+
+// registryAuthFromBase64 decodes a BuildOptions.RegistryAuth override, which
+// matches Docker's X-Registry-Auth convention: a base64-encoded
+// {"username":"...","password":"..."} blob.
+func registryAuthFromBase64(encoded string) (authn.Authenticator, error) {
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode RegistryAuth: %w", err)
+	}
+
+	var cfg authn.AuthConfig
+	if err := json.Unmarshal(decoded, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse RegistryAuth: %w", err)
+	}
+
+	return authn.FromConfig(cfg), nil
+}
+
+// registryAuthContextKey carries a BuildOptions.RegistryAuth override
+// through to the concrete ImageSaver without changing the ImageSaver
+// interface's signature.
+type registryAuthContextKey struct{}
+
+func withRegistryAuth(ctx context.Context, registryAuth string) context.Context {
+	if registryAuth == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, registryAuthContextKey{}, registryAuth)
+}
+
+func registryAuthFromContext(ctx context.Context) (string, bool) {
+	registryAuth, ok := ctx.Value(registryAuthContextKey{}).(string)
+	return registryAuth, ok
+}
+
+// resolveAuthOption returns the remote.Option an ImageSaver should use to
+// authenticate: the BuildOptions.RegistryAuth override carried on ctx if
+// present, otherwise the saver's keychain.
+func resolveAuthOption(ctx context.Context, keychain authn.Keychain) (remoteAuthOption, error) {
+	if registryAuth, ok := registryAuthFromContext(ctx); ok {
+		authenticator, err := registryAuthFromBase64(registryAuth)
+		if err != nil {
+			return remoteAuthOption{}, err
+		}
+		return remoteAuthOption{authenticator: authenticator}, nil
+	}
+
+	return remoteAuthOption{keychain: keychain}, nil
+}
+
+// remoteAuthOption carries either a resolved Authenticator (from a
+// RegistryAuth override) or a Keychain to resolve one from, so callers can
+// pick the right remote.Option to pass to remote.Write/remote.WriteIndex.
+type remoteAuthOption struct {
+	authenticator authn.Authenticator
+	keychain      authn.Keychain
+}
@@ -0,0 +1,189 @@
+package builder
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResource struct {
+	registry string
+}
+
+func (f fakeResource) String() string      { return f.registry }
+func (f fakeResource) RegistryStr() string { return f.registry }
+
+// fakeKeychain is an authn.Keychain that records whether it was asked to
+// resolve credentials, so tests can assert a caller used the configured
+// Keychain instead of falling back to authn.DefaultKeychain.
+type fakeKeychain struct {
+	resolved bool
+}
+
+func (f *fakeKeychain) Resolve(authn.Resource) (authn.Authenticator, error) {
+	f.resolved = true
+	return authn.Anonymous, nil
+}
+
+func writeDockerConfig(t *testing.T, cfg dockerConfigFile) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	data, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), data, 0600))
+	t.Setenv("DOCKER_CONFIG", dir)
+
+	return dir
+}
+
+// writeFakeCredentialHelper installs a docker-credential-<name> script on
+// PATH that echoes back fixed credentials, simulating the real
+// docker-credential-* stdin/stdout protocol.
+func writeFakeCredentialHelper(t *testing.T, name, username, secret string) {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("fake credential helper script is not portable to windows")
+	}
+
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"read registry\n" +
+		`printf '{"ServerURL":"%s","Username":"` + username + `","Secret":"` + secret + `"}' "$registry"` + "\n"
+	path := filepath.Join(dir, "docker-credential-"+name)
+	require.NoError(t, os.WriteFile(path, []byte(script), 0700))
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestKeychain_Resolve_DockerConfigAuths(t *testing.T) {
+	writeDockerConfig(t, dockerConfigFile{
+		Auths: map[string]dockerConfigAuth{
+			"registry.example.com": {Auth: base64.StdEncoding.EncodeToString([]byte("alice:hunter2"))},
+		},
+	})
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+
+	k := &Keychain{}
+	auth, err := k.Resolve(fakeResource{registry: "registry.example.com"})
+	require.NoError(t, err)
+
+	cfg, err := auth.Authorization()
+	require.NoError(t, err)
+	assert.Equal(t, "alice", cfg.Username)
+	assert.Equal(t, "hunter2", cfg.Password)
+}
+
+func TestKeychain_Resolve_CredHelper(t *testing.T) {
+	writeFakeCredentialHelper(t, "test", "helperuser", "helperpass")
+	writeDockerConfig(t, dockerConfigFile{
+		CredHelpers: map[string]string{"registry.example.com": "test"},
+	})
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+
+	k := &Keychain{}
+	auth, err := k.Resolve(fakeResource{registry: "registry.example.com"})
+	require.NoError(t, err)
+
+	cfg, err := auth.Authorization()
+	require.NoError(t, err)
+	assert.Equal(t, "helperuser", cfg.Username)
+	assert.Equal(t, "helperpass", cfg.Password)
+}
+
+func TestKeychain_Resolve_CredsStore(t *testing.T) {
+	writeFakeCredentialHelper(t, "store", "storeuser", "storepass")
+	writeDockerConfig(t, dockerConfigFile{
+		CredsStore: "store",
+	})
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+
+	k := &Keychain{}
+	auth, err := k.Resolve(fakeResource{registry: "registry.example.com"})
+	require.NoError(t, err)
+
+	cfg, err := auth.Authorization()
+	require.NoError(t, err)
+	assert.Equal(t, "storeuser", cfg.Username)
+	assert.Equal(t, "storepass", cfg.Password)
+}
+
+func TestKeychain_Resolve_PodmanAuthFallback(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir()) // no config.json present
+
+	runtimeDir := t.TempDir()
+	containersDir := filepath.Join(runtimeDir, "containers")
+	require.NoError(t, os.MkdirAll(containersDir, 0755))
+
+	authFile := podmanAuthFile{
+		Auths: map[string]dockerConfigAuth{
+			"registry.example.com": {Auth: base64.StdEncoding.EncodeToString([]byte("podman:secret"))},
+		},
+	}
+	data, err := json.Marshal(authFile)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(containersDir, "auth.json"), data, 0600))
+	t.Setenv("XDG_RUNTIME_DIR", runtimeDir)
+
+	k := &Keychain{}
+	auth, err := k.Resolve(fakeResource{registry: "registry.example.com"})
+	require.NoError(t, err)
+
+	cfg, err := auth.Authorization()
+	require.NoError(t, err)
+	assert.Equal(t, "podman", cfg.Username)
+	assert.Equal(t, "secret", cfg.Password)
+}
+
+func TestKeychain_Resolve_EnvFallback(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	t.Setenv("DOCKER_USERNAME", "envuser")
+	t.Setenv("DOCKER_PASSWORD", "envpass")
+
+	k := &Keychain{}
+	auth, err := k.Resolve(fakeResource{registry: "registry.example.com"})
+	require.NoError(t, err)
+
+	cfg, err := auth.Authorization()
+	require.NoError(t, err)
+	assert.Equal(t, "envuser", cfg.Username)
+	assert.Equal(t, "envpass", cfg.Password)
+}
+
+func TestKeychain_Resolve_Anonymous(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	t.Setenv("DOCKER_USERNAME", "")
+	t.Setenv("DOCKER_PASSWORD", "")
+
+	k := &Keychain{}
+	auth, err := k.Resolve(fakeResource{registry: "registry.example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, authn.Anonymous, auth)
+}
+
+func TestRegistryAuthFromBase64(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString([]byte(`{"username":"bob","password":"swordfish"}`))
+
+	auth, err := registryAuthFromBase64(encoded)
+	require.NoError(t, err)
+
+	cfg, err := auth.Authorization()
+	require.NoError(t, err)
+	assert.Equal(t, "bob", cfg.Username)
+	assert.Equal(t, "swordfish", cfg.Password)
+}
+
+func TestRegistryAuthFromBase64_InvalidBase64(t *testing.T) {
+	_, err := registryAuthFromBase64("not-base64!!!")
+	assert.Error(t, err)
+}
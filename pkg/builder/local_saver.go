@@ -0,0 +1,213 @@
+package builder
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+)
+
+// TarballImageSaver implements ImageSaver by writing a "docker save"-compatible
+// tarball to a local path, selected via BuildOptions.Output's
+// "docker-archive://path.tar" scheme.
+type TarballImageSaver struct {
+	Path string
+}
+
+func (t *TarballImageSaver) SaveImage(ctx context.Context, img v1.Image, ref string) error {
+	tag, err := name.NewTag(ref)
+	if err != nil {
+		return fmt.Errorf("failed to parse tag %s: %w", ref, err)
+	}
+
+	if err := tarball.WriteToFile(t.Path, tag, img); err != nil {
+		return fmt.Errorf("failed to write docker archive to %s: %w", t.Path, err)
+	}
+
+	return nil
+}
+
+// SaveImageIndex writes every platform image in idx to a single tarball,
+// each under a platform-suffixed tag, since docker-archive tarballs have no
+// native manifest list support. This mirrors DaemonImageSaver.SaveImageIndex.
+func (t *TarballImageSaver) SaveImageIndex(ctx context.Context, idx v1.ImageIndex, ref string) error {
+	manifest, err := idx.IndexManifest()
+	if err != nil {
+		return fmt.Errorf("failed to get index manifest: %w", err)
+	}
+
+	baseTag, err := name.NewTag(ref)
+	if err != nil {
+		return fmt.Errorf("failed to parse tag %s: %w", ref, err)
+	}
+
+	refToImage := make(map[name.Reference]v1.Image, len(manifest.Manifests))
+
+	for _, desc := range manifest.Manifests {
+		if desc.Platform == nil {
+			continue
+		}
+
+		img, err := idx.Image(desc.Digest)
+		if err != nil {
+			return fmt.Errorf("failed to get image for platform %s/%s: %w", desc.Platform.OS, desc.Platform.Architecture, err)
+		}
+
+		platformTag, err := name.NewTag(fmt.Sprintf("%s-%s-%s", baseTag.String(), desc.Platform.OS, desc.Platform.Architecture))
+		if err != nil {
+			return fmt.Errorf("failed to create platform tag: %w", err)
+		}
+
+		refToImage[platformTag] = img
+	}
+
+	if err := tarball.MultiRefWriteToFile(t.Path, refToImage); err != nil {
+		return fmt.Errorf("failed to write docker archive to %s: %w", t.Path, err)
+	}
+
+	return nil
+}
+
+// OCILayoutImageSaver implements ImageSaver by writing an OCI image layout
+// directory (oci-layout, index.json, blobs/sha256/...) to a local path,
+// selected via BuildOptions.Output's "oci-layout://dir" scheme.
+type OCILayoutImageSaver struct {
+	Dir string
+}
+
+func (o *OCILayoutImageSaver) SaveImage(ctx context.Context, img v1.Image, ref string) error {
+	idx := mutate.AppendManifests(empty.Index, mutate.IndexAddendum{
+		Add: img,
+		Descriptor: v1.Descriptor{
+			Annotations: map[string]string{ImageRefNameLabel: ref},
+		},
+	})
+
+	return o.SaveImageIndex(ctx, idx, ref)
+}
+
+func (o *OCILayoutImageSaver) SaveImageIndex(ctx context.Context, idx v1.ImageIndex, ref string) error {
+	if _, err := layout.Write(o.Dir, idx); err != nil {
+		return fmt.Errorf("failed to write OCI layout to %s: %w", o.Dir, err)
+	}
+
+	return nil
+}
+
+// OCIArchiveImageSaver implements ImageSaver by writing a tarred OCI image
+// layout to a local path, selected via BuildOptions.Output's
+// "oci-archive://path.tar" scheme. go-containerregistry has no native writer
+// for this format, so the layout is written to a temporary directory and
+// then archived.
+type OCIArchiveImageSaver struct {
+	Path string
+}
+
+func (o *OCIArchiveImageSaver) SaveImage(ctx context.Context, img v1.Image, ref string) error {
+	idx := mutate.AppendManifests(empty.Index, mutate.IndexAddendum{
+		Add: img,
+		Descriptor: v1.Descriptor{
+			Annotations: map[string]string{ImageRefNameLabel: ref},
+		},
+	})
+
+	return o.SaveImageIndex(ctx, idx, ref)
+}
+
+func (o *OCIArchiveImageSaver) SaveImageIndex(ctx context.Context, idx v1.ImageIndex, ref string) error {
+	tmpDir, err := os.MkdirTemp("", "genmcp-oci-archive-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary OCI layout directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	if _, err := layout.Write(tmpDir, idx); err != nil {
+		return fmt.Errorf("failed to write OCI layout to %s: %w", tmpDir, err)
+	}
+
+	if err := tarDirectory(tmpDir, o.Path); err != nil {
+		return fmt.Errorf("failed to archive OCI layout to %s: %w", o.Path, err)
+	}
+
+	return nil
+}
+
+// tarDirectory writes every file under src into a tarball at dest, with
+// entry names relative to src.
+func tarDirectory(src, dest string) error {
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = out.Close() }()
+
+	tw := tar.NewWriter(out)
+	defer func() { _ = tw.Close() }()
+
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if relPath == "." {
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relPath)
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+}
+
+// parseOutputSaver returns the ImageSaver that output selects
+// ("docker-archive://path.tar", "oci-archive://path.tar", or
+// "oci-layout://dir"), or nil if output is empty or uses the "registry://"
+// scheme, meaning the ImageBuilder's configured saver (registry or local
+// container engine) should be used instead.
+func parseOutputSaver(output string) (ImageSaver, error) {
+	switch {
+	case output == "" || strings.HasPrefix(output, "registry://"):
+		return nil, nil
+	case strings.HasPrefix(output, "docker-archive://"):
+		return &TarballImageSaver{Path: strings.TrimPrefix(output, "docker-archive://")}, nil
+	case strings.HasPrefix(output, "oci-archive://"):
+		return &OCIArchiveImageSaver{Path: strings.TrimPrefix(output, "oci-archive://")}, nil
+	case strings.HasPrefix(output, "oci-layout://"):
+		return &OCILayoutImageSaver{Dir: strings.TrimPrefix(output, "oci-layout://")}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output %q: must use the registry://, docker-archive://, oci-archive://, or oci-layout:// scheme", output)
+	}
+}
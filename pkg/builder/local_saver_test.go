@@ -0,0 +1,152 @@
+package builder
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutputSaver(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		wantSaver ImageSaver
+		wantErr   bool
+	}{
+		{
+			name:      "empty output uses the configured saver",
+			output:    "",
+			wantSaver: nil,
+		},
+		{
+			name:      "registry scheme uses the configured saver",
+			output:    "registry://quay.io/example/image:latest",
+			wantSaver: nil,
+		},
+		{
+			name:      "docker-archive",
+			output:    "docker-archive://out.tar",
+			wantSaver: &TarballImageSaver{Path: "out.tar"},
+		},
+		{
+			name:      "oci-archive",
+			output:    "oci-archive://out.tar",
+			wantSaver: &OCIArchiveImageSaver{Path: "out.tar"},
+		},
+		{
+			name:      "oci-layout",
+			output:    "oci-layout://out-dir",
+			wantSaver: &OCILayoutImageSaver{Dir: "out-dir"},
+		},
+		{
+			name:    "unsupported scheme",
+			output:  "ssh://example.com/image.tar",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saver, err := parseOutputSaver(tt.output)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSaver, saver)
+		})
+	}
+}
+
+func TestTarballImageSaver_SaveImage(t *testing.T) {
+	img, err := random.Image(1024, 1)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "out.tar")
+	saver := &TarballImageSaver{Path: path}
+	require.NoError(t, saver.SaveImage(context.Background(), img, "example.com/repo:tag"))
+
+	loaded, err := tarball.ImageFromPath(path, nil)
+	require.NoError(t, err)
+
+	wantDigest, err := img.Digest()
+	require.NoError(t, err)
+	gotDigest, err := loaded.Digest()
+	require.NoError(t, err)
+	assert.Equal(t, wantDigest, gotDigest)
+}
+
+func TestTarballImageSaver_SaveImageIndex(t *testing.T) {
+	idx := buildTestIndex(t)
+
+	path := filepath.Join(t.TempDir(), "out.tar")
+	saver := &TarballImageSaver{Path: path}
+	require.NoError(t, saver.SaveImageIndex(context.Background(), idx, "example.com/repo:tag"))
+
+	platformTag, err := name.NewTag("example.com/repo:tag-linux-amd64")
+	require.NoError(t, err)
+
+	loaded, err := tarball.ImageFromPath(path, &platformTag)
+	require.NoError(t, err)
+	_, err = loaded.Digest()
+	require.NoError(t, err)
+}
+
+func TestOCILayoutImageSaver(t *testing.T) {
+	img, err := random.Image(1024, 1)
+	require.NoError(t, err)
+
+	dir := filepath.Join(t.TempDir(), "layout")
+	saver := &OCILayoutImageSaver{Dir: dir}
+	require.NoError(t, saver.SaveImage(context.Background(), img, "example.com/repo:tag"))
+
+	assert.FileExists(t, filepath.Join(dir, "oci-layout"))
+	assert.FileExists(t, filepath.Join(dir, "index.json"))
+
+	path, err := layout.FromPath(dir)
+	require.NoError(t, err)
+	_, err = path.ImageIndex()
+	require.NoError(t, err)
+}
+
+func TestOCIArchiveImageSaver(t *testing.T) {
+	img, err := random.Image(1024, 1)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "out.tar")
+	saver := &OCIArchiveImageSaver{Path: path}
+	require.NoError(t, saver.SaveImage(context.Background(), img, "example.com/repo:tag"))
+
+	assert.FileExists(t, path)
+}
+
+func buildTestIndex(t *testing.T) v1.ImageIndex {
+	t.Helper()
+
+	linuxAmd64, err := random.Image(1024, 1)
+	require.NoError(t, err)
+	linuxAmd64, err = mutate.ConfigFile(linuxAmd64, &v1.ConfigFile{
+		Architecture: "amd64",
+		OS:           "linux",
+	})
+	require.NoError(t, err)
+
+	idx := mutate.AppendManifests(empty.Index, mutate.IndexAddendum{
+		Add: linuxAmd64,
+		Descriptor: v1.Descriptor{
+			Platform: &v1.Platform{OS: "linux", Architecture: "amd64"},
+		},
+	})
+
+	return idx
+}
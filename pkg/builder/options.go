@@ -0,0 +1,92 @@
+package builder
+
+import (
+	"io"
+
+	"github.com/google/go-containerregistry/pkg/v1"
+)
+
+const DefaultBaseImage = "registry.access.redhat.com/ubi9/ubi-minimal:latest"
+
+type BuildOptions struct {
+	Platform    *v1.Platform // Target platform (linux/amd64, etc.)
+	BaseImage   string       // Base image reference
+	MCPFilePath string       // path to the mcp file
+	ImageTag    string       // output image tag
+
+	// Sign, if true, makes ImageBuilder.SaveAndSign sign the image with
+	// SigningOptions after it's pushed.
+	Sign           bool
+	SigningOptions SigningOptions
+
+	// EncryptionConfig, when it has recipients, makes Build encrypt the MCP
+	// file layer (and optionally the binary layer) for those recipients.
+	EncryptionConfig EncryptionConfig
+
+	// RegistryAuth overrides the registry credentials an ImageSaver would
+	// otherwise resolve via its Keychain for this call only. It matches
+	// Docker's X-Registry-Auth convention: a base64-encoded
+	// {"username":"...","password":"..."} blob.
+	RegistryAuth string
+
+	// Output selects where ImageBuilder.SaveAndSign writes the built image,
+	// as a transport-prefixed URI: "registry://" (or empty) to use the
+	// ImageBuilder's configured saver, "docker-archive://path.tar" for a
+	// docker save-compatible tarball, "oci-archive://path.tar" for a tarred
+	// OCI image layout, or "oci-layout://dir" for an OCI image layout
+	// directory.
+	Output string
+
+	// ProgressWriter, when set, makes Build emit one JSON object per line to
+	// it for each build phase, using the same shape as `docker build`'s
+	// JSON-lines progress output ({"stream":"..."}, {"status":"...",
+	// "progressDetail":{"current":N,"total":M},"id":"..."}, and a final
+	// {"aux":{"ID":"sha256:..."}}). Writes are best-effort; marshal or write
+	// errors are silently ignored so a broken progress sink never fails a
+	// build.
+	ProgressWriter io.Writer
+
+	// SuppressOutput, when true, makes Build emit only the final "aux" event
+	// to ProgressWriter, skipping the intermediate "stream" and "status"
+	// events.
+	SuppressOutput bool
+}
+
+func (o *BuildOptions) SetDefaults() {
+	if o.BaseImage == "" {
+		o.BaseImage = DefaultBaseImage
+	}
+	if o.Platform == nil {
+		o.Platform = &v1.Platform{OS: "linux", Architecture: "amd64"}
+	}
+}
+
+// MultiArchBuildOptions configures ImageBuilder.BuildMultiArch, which builds
+// one image per platform and publishes them under a single OCI image index.
+type MultiArchBuildOptions struct {
+	Platforms   []*v1.Platform // Target platforms
+	BaseImage   string         // Base image reference
+	MCPFilePath string         // path to the mcp file
+	ImageTag    string         // output image tag
+
+	// Output selects where ImageBuilder.SaveIndexTo writes the built index.
+	// See BuildOptions.Output for the supported schemes.
+	Output string
+
+	// ProgressWriter and SuppressOutput are forwarded to the BuildOptions
+	// used for each platform. See BuildOptions for their meaning.
+	ProgressWriter io.Writer
+	SuppressOutput bool
+}
+
+func (o *MultiArchBuildOptions) SetDefaults() {
+	if o.BaseImage == "" {
+		o.BaseImage = DefaultBaseImage
+	}
+	if len(o.Platforms) == 0 {
+		o.Platforms = []*v1.Platform{
+			{OS: "linux", Architecture: "amd64"},
+			{OS: "linux", Architecture: "arm64"},
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package builder
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// progressEvent is a single JSON-lines progress event, matching the shape
+// `docker build` emits on its API: a free-form log line via Stream, a
+// phase/layer update via Status+ID+ProgressDetail, or a terminal result via
+// Aux.
+type progressEvent struct {
+	Stream         string          `json:"stream,omitempty"`
+	Status         string          `json:"status,omitempty"`
+	ID             string          `json:"id,omitempty"`
+	ProgressDetail *progressDetail `json:"progressDetail,omitempty"`
+	Aux            map[string]any  `json:"aux,omitempty"`
+}
+
+type progressDetail struct {
+	Current int64 `json:"current"`
+	Total   int64 `json:"total"`
+}
+
+// progressEmitter writes progressEvents to an io.Writer as JSON lines.
+// A nil writer, or a nil *progressEmitter itself, makes every method a no-op,
+// so callers can build one unconditionally and ignore the "is progress
+// reporting enabled" question everywhere else.
+type progressEmitter struct {
+	w     io.Writer
+	quiet bool
+}
+
+func newProgressEmitter(w io.Writer, quiet bool) *progressEmitter {
+	if w == nil {
+		return nil
+	}
+	return &progressEmitter{w: w, quiet: quiet}
+}
+
+// stream emits a free-form log line, equivalent to `docker build`'s
+// {"stream":"..."} events.
+func (p *progressEmitter) stream(line string) {
+	if p == nil || p.quiet {
+		return
+	}
+	p.emit(progressEvent{Stream: line})
+}
+
+// status emits a phase/layer update. A total of 0 omits progressDetail,
+// for phases that don't report byte-level progress.
+func (p *progressEmitter) status(status, id string, current, total int64) {
+	if p == nil || p.quiet {
+		return
+	}
+
+	var detail *progressDetail
+	if total > 0 {
+		detail = &progressDetail{Current: current, Total: total}
+	}
+
+	p.emit(progressEvent{Status: status, ID: id, ProgressDetail: detail})
+}
+
+// aux emits the terminal event carrying the built image's digest. It is
+// emitted even in quiet mode, since it's the one event quiet mode exists to
+// preserve.
+func (p *progressEmitter) aux(digest string) {
+	if p == nil {
+		return
+	}
+	p.emit(progressEvent{Aux: map[string]any{"ID": digest}})
+}
+
+func (p *progressEmitter) emit(ev progressEvent) {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return
+	}
+	data = append(data, '\n')
+	_, _ = p.w.Write(data)
+}
+
+// serializedWriter is an io.Writer that funnels Write calls from multiple
+// goroutines through a single goroutine that performs the real writes, so
+// that concurrent callers (e.g. one progressEmitter per platform in
+// BuildMultiArch) can safely share an underlying io.Writer that doesn't
+// synchronize access itself, such as os.Stdout or a *bytes.Buffer.
+type serializedWriter struct {
+	writes chan []byte
+	done   chan struct{}
+}
+
+func newSerializedWriter(w io.Writer) *serializedWriter {
+	sw := &serializedWriter{
+		writes: make(chan []byte, 64),
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(sw.done)
+		for data := range sw.writes {
+			_, _ = w.Write(data)
+		}
+	}()
+
+	return sw
+}
+
+// Write copies data and queues it to be written by the single draining
+// goroutine, so it's safe to call concurrently from multiple goroutines.
+func (sw *serializedWriter) Write(data []byte) (int, error) {
+	buf := make([]byte, len(data))
+	copy(buf, data)
+	sw.writes <- buf
+	return len(data), nil
+}
+
+// Close stops accepting writes and blocks until every queued write has been
+// flushed to the underlying writer.
+func (sw *serializedWriter) Close() {
+	close(sw.writes)
+	<-sw.done
+}
@@ -0,0 +1,74 @@
+package builder
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressEmitter_NilWriterIsNoOp(t *testing.T) {
+	emitter := newProgressEmitter(nil, false)
+	assert.Nil(t, emitter)
+
+	// every method must tolerate a nil receiver
+	emitter.stream("line\n")
+	emitter.status("Downloading", "base-image", 1, 2)
+	emitter.aux("sha256:test")
+}
+
+func TestProgressEmitter_Quiet(t *testing.T) {
+	var buf bytes.Buffer
+	emitter := newProgressEmitter(&buf, true)
+
+	emitter.stream("pulling base image\n")
+	emitter.status("Downloading", "base-image", 1, 2)
+	emitter.aux("sha256:test")
+
+	lines := decodeJSONLines(t, buf.Bytes())
+	require.Len(t, lines, 1, "quiet mode should only emit the final aux event")
+	assert.Equal(t, map[string]any{"aux": map[string]any{"ID": "sha256:test"}}, lines[0])
+}
+
+func TestProgressEmitter_EmitsExpectedShapes(t *testing.T) {
+	var buf bytes.Buffer
+	emitter := newProgressEmitter(&buf, false)
+
+	emitter.stream("pulling base image example.com/base:latest\n")
+	emitter.status("Downloading", "base-image", 50, 100)
+	emitter.status("Downloading", "no-total-id", 0, 0)
+	emitter.aux("sha256:abc123")
+
+	lines := decodeJSONLines(t, buf.Bytes())
+	require.Len(t, lines, 4)
+
+	assert.Equal(t, map[string]any{"stream": "pulling base image example.com/base:latest\n"}, lines[0])
+	assert.Equal(t, map[string]any{
+		"status": "Downloading",
+		"id":     "base-image",
+		"progressDetail": map[string]any{
+			"current": float64(50),
+			"total":   float64(100),
+		},
+	}, lines[1])
+	assert.Equal(t, map[string]any{"status": "Downloading", "id": "no-total-id"}, lines[2])
+	assert.Equal(t, map[string]any{"aux": map[string]any{"ID": "sha256:abc123"}}, lines[3])
+}
+
+func decodeJSONLines(t *testing.T, data []byte) []map[string]any {
+	t.Helper()
+
+	var lines []map[string]any
+	for _, line := range bytes.Split(bytes.TrimSpace(data), []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		var event map[string]any
+		require.NoError(t, json.Unmarshal(line, &event))
+		lines = append(lines, event)
+	}
+
+	return lines
+}
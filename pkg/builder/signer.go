@@ -0,0 +1,290 @@
+package builder
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/youmark/pkcs8"
+)
+
+// SignatureAnnotation is the OCI annotation cosign stores a detached
+// signature's bytes under on the synthetic signature layer.
+const SignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+// simpleSigningMediaType is the media type cosign uses for the "simple
+// signing" payload it signs.
+const simpleSigningMediaType = types.MediaType("application/vnd.dev.cosign.simplesigning.v1+json")
+
+// SigningOptions configures how a Signer loads the key used to sign images.
+type SigningOptions struct {
+	// KeyPath is the path to a PEM-encoded ECDSA or ED25519 private key,
+	// either unencrypted (a "EC PRIVATE KEY" or "PRIVATE KEY" PEM block) or
+	// encrypted with PasswordEnvVar (a standard PKCS#8 "ENCRYPTED PRIVATE
+	// KEY" block, the format written by e.g. `openssl pkcs8 -topk8`).
+	KeyPath string
+	// PasswordEnvVar names the environment variable holding the password
+	// used to decrypt KeyPath, if it's encrypted.
+	PasswordEnvVar string
+	// KMSKeyURI identifies a KMS-backed key (e.g. "awskms://...", "gcpkms://...").
+	// KMS-backed keys are not supported yet.
+	KMSKeyURI string
+}
+
+// Signer produces and publishes a cosign-compatible signature for an image
+// that was pushed to ref at the given digest.
+type Signer interface {
+	SignImage(ctx context.Context, ref string, digest v1.Hash) error
+}
+
+// cosignPayload is the "simple signing" payload cosign signs, also used by
+// containers/image.
+type cosignPayload struct {
+	Critical cosignCritical `json:"critical"`
+	Optional map[string]any `json:"optional,omitempty"`
+}
+
+type cosignCritical struct {
+	Identity cosignIdentity `json:"identity"`
+	Image    cosignImage    `json:"image"`
+	Type     string         `json:"type"`
+}
+
+type cosignIdentity struct {
+	DockerReference string `json:"docker-reference"`
+}
+
+type cosignImage struct {
+	DockerManifestDigest string `json:"docker-manifest-digest"`
+}
+
+// KeySigner is the default Signer. It signs the cosign simple-signing
+// payload with an ECDSA private key and pushes the signature as an OCI
+// artifact tagged "<repo>:sha256-<hex>.sig", the same layout cosign itself
+// uses so the result can be verified with the real cosign CLI.
+type KeySigner struct {
+	Options SigningOptions
+	// Keychain resolves registry credentials for pushing the signature.
+	// Defaults to DefaultKeychain when nil.
+	Keychain authn.Keychain
+}
+
+func NewKeySigner(opts SigningOptions) *KeySigner {
+	return &KeySigner{Options: opts}
+}
+
+func (s *KeySigner) keychain() authn.Keychain {
+	if s.Keychain != nil {
+		return s.Keychain
+	}
+	return DefaultKeychain
+}
+
+func (s *KeySigner) SignImage(ctx context.Context, ref string, digest v1.Hash) error {
+	key, err := s.loadPrivateKey()
+	if err != nil {
+		return fmt.Errorf("failed to load signing key: %w", err)
+	}
+
+	payload, err := simpleSigningPayload(ref, digest)
+	if err != nil {
+		return err
+	}
+
+	sig, err := signPayload(key, payload)
+	if err != nil {
+		return fmt.Errorf("failed to sign image: %w", err)
+	}
+
+	sigImg, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer: static.NewLayer(payload, simpleSigningMediaType),
+		Annotations: map[string]string{
+			SignatureAnnotation: base64.StdEncoding.EncodeToString(sig),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to assemble signature image: %w", err)
+	}
+
+	sigRef, err := signatureReference(ref, digest)
+	if err != nil {
+		return err
+	}
+
+	authOpt, err := resolveAuthOption(ctx, s.keychain())
+	if err != nil {
+		return fmt.Errorf("failed to resolve registry credentials for %s: %w", sigRef, err)
+	}
+
+	if err := remote.Write(sigRef, sigImg, remote.WithContext(ctx), remoteAuthOptionFor(authOpt)); err != nil {
+		return fmt.Errorf("failed to push signature to %s: %w", sigRef, err)
+	}
+
+	return nil
+}
+
+// Verify reports whether sig is a valid signature over ref/digest's
+// simple-signing payload under pub, which must be an *ecdsa.PublicKey or an
+// ed25519.PublicKey. It exists so tests can round-trip KeySigner without
+// needing a registry to push to.
+func (s *KeySigner) Verify(pub crypto.PublicKey, ref string, digest v1.Hash, sig []byte) error {
+	payload, err := simpleSigningPayload(ref, digest)
+	if err != nil {
+		return err
+	}
+
+	var valid bool
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		hashed := sha256.Sum256(payload)
+		valid = ecdsa.VerifyASN1(pub, hashed[:], sig)
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(pub, payload, sig)
+	default:
+		return fmt.Errorf("unsupported public key type %T: only ECDSA and ED25519 keys are supported", pub)
+	}
+
+	if !valid {
+		return errors.New("signature verification failed")
+	}
+
+	return nil
+}
+
+// loadPrivateKey loads the ECDSA or ED25519 private key configured by
+// s.Options, decrypting it first if it's encrypted.
+func (s *KeySigner) loadPrivateKey() (crypto.Signer, error) {
+	if s.Options.KMSKeyURI != "" {
+		return nil, errors.New("KMS-backed signing keys are not supported yet")
+	}
+
+	if s.Options.KeyPath == "" {
+		return nil, errors.New("no signing key configured")
+	}
+
+	keyBytes, err := os.ReadFile(s.Options.KeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key %s: %w", s.Options.KeyPath, err)
+	}
+
+	block, _ := pem.Decode(keyBytes)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", s.Options.KeyPath)
+	}
+
+	if !strings.Contains(block.Type, "ENCRYPTED") {
+		key, err := parseSigningKeyDER(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse signing key %s: %w", s.Options.KeyPath, err)
+		}
+		return key, nil
+	}
+
+	if s.Options.PasswordEnvVar == "" {
+		return nil, fmt.Errorf("signing key %s is encrypted but no PasswordEnvVar is configured", s.Options.KeyPath)
+	}
+
+	password, ok := os.LookupEnv(s.Options.PasswordEnvVar)
+	if !ok {
+		return nil, fmt.Errorf("environment variable %s is not set", s.Options.PasswordEnvVar)
+	}
+
+	raw, _, err := pkcs8.ParsePrivateKey(block.Bytes, []byte(password))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt signing key %s: %w", s.Options.KeyPath, err)
+	}
+
+	key, err := signerFromParsedKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt signing key %s: %w", s.Options.KeyPath, err)
+	}
+
+	return key, nil
+}
+
+// parseSigningKeyDER parses der as an ECDSA (SEC1 or PKCS8) or ED25519
+// (PKCS8) private key.
+func parseSigningKeyDER(der []byte) (crypto.Signer, error) {
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported signing key format: %w", err)
+	}
+
+	return signerFromParsedKey(key)
+}
+
+// signerFromParsedKey narrows a key parsed by x509/pkcs8 down to the
+// *ecdsa.PrivateKey and ed25519.PrivateKey types this package signs with.
+func signerFromParsedKey(key any) (crypto.Signer, error) {
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey:
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T: only ECDSA and ED25519 keys are supported", key)
+	}
+}
+
+func simpleSigningPayload(ref string, digest v1.Hash) ([]byte, error) {
+	payload, err := json.Marshal(cosignPayload{
+		Critical: cosignCritical{
+			Identity: cosignIdentity{DockerReference: ref},
+			Image:    cosignImage{DockerManifestDigest: digest.String()},
+			Type:     "cosign container image signature",
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal signature payload: %w", err)
+	}
+
+	return payload, nil
+}
+
+// signPayload signs payload with key, which must be an *ecdsa.PrivateKey or
+// an ed25519.PrivateKey. ED25519 signs the payload directly; ECDSA signs its
+// SHA-256 digest, matching cosign's simple-signing convention.
+func signPayload(key crypto.Signer, payload []byte) ([]byte, error) {
+	if _, ok := key.(ed25519.PrivateKey); ok {
+		return key.Sign(rand.Reader, payload, crypto.Hash(0))
+	}
+
+	hashed := sha256.Sum256(payload)
+	return key.Sign(rand.Reader, hashed[:], crypto.SHA256)
+}
+
+// signatureReference derives the cosign-style signature tag for ref, e.g.
+// "example.com/repo:sha256-<hex>.sig".
+func signatureReference(ref string, digest v1.Hash) (name.Reference, error) {
+	repo, err := name.ParseReference(ref)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reference %s: %w", ref, err)
+	}
+
+	sigTag := strings.ReplaceAll(digest.String(), ":", "-") + ".sig"
+
+	return name.NewTag(repo.Context().Name() + ":" + sigTag)
+}
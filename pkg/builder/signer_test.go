@@ -0,0 +1,227 @@
+package builder
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youmark/pkcs8"
+)
+
+func generateTestKeyFile(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	block := &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}
+
+	path := filepath.Join(t.TempDir(), "cosign.key")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(block), 0600))
+
+	return key, path
+}
+
+func generateTestEd25519KeyFile(t *testing.T) (ed25519.PublicKey, string) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+
+	block := &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+
+	path := filepath.Join(t.TempDir(), "cosign-ed25519.key")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(block), 0600))
+
+	return pub, path
+}
+
+// generateEncryptedTestKeyFile writes an ECDSA key encrypted into a standard
+// PKCS#8 EncryptedPrivateKeyInfo PEM block, the same format produced by e.g.
+// `openssl pkcs8 -topk8 -v2 aes-256-cbc`.
+func generateEncryptedTestKeyFile(t *testing.T, password string) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := pkcs8.MarshalPrivateKey(key, []byte(password), nil)
+	require.NoError(t, err)
+
+	block := &pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: der}
+
+	path := filepath.Join(t.TempDir(), "encrypted-cosign.key")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(block), 0600))
+
+	return key, path
+}
+
+func TestKeySigner_SignAndVerify(t *testing.T) {
+	key, keyPath := generateTestKeyFile(t)
+	ref := "example.com/test/image:latest"
+	digest := v1.Hash{Algorithm: "sha256", Hex: "deadbeef00000000000000000000000000000000000000000000000000000000"[:64]}
+
+	signer := NewKeySigner(SigningOptions{KeyPath: keyPath})
+
+	privateKey, err := signer.loadPrivateKey()
+	require.NoError(t, err)
+
+	payload, err := simpleSigningPayload(ref, digest)
+	require.NoError(t, err)
+
+	sig, err := signPayload(privateKey, payload)
+	require.NoError(t, err)
+
+	assert.NoError(t, signer.Verify(&key.PublicKey, ref, digest, sig))
+}
+
+func TestKeySigner_Verify_RejectsTamperedSignature(t *testing.T) {
+	key, keyPath := generateTestKeyFile(t)
+	ref := "example.com/test/image:latest"
+	digest := v1.Hash{Algorithm: "sha256", Hex: "deadbeef00000000000000000000000000000000000000000000000000000000"[:64]}
+
+	signer := NewKeySigner(SigningOptions{KeyPath: keyPath})
+
+	privateKey, err := signer.loadPrivateKey()
+	require.NoError(t, err)
+
+	payload, err := simpleSigningPayload(ref, digest)
+	require.NoError(t, err)
+
+	sig, err := signPayload(privateKey, payload)
+	require.NoError(t, err)
+
+	otherDigest := v1.Hash{Algorithm: "sha256", Hex: "0000000000000000000000000000000000000000000000000000000000000000"[:64]}
+	assert.Error(t, signer.Verify(&key.PublicKey, ref, otherDigest, sig))
+}
+
+func TestKeySigner_SignImage_NoKeyConfigured(t *testing.T) {
+	signer := NewKeySigner(SigningOptions{})
+
+	err := signer.SignImage(context.Background(), "example.com/test/image:latest", v1.Hash{Algorithm: "sha256", Hex: "deadbeef"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no signing key configured")
+}
+
+func TestKeySigner_SignAndVerify_ED25519(t *testing.T) {
+	pub, keyPath := generateTestEd25519KeyFile(t)
+	ref := "example.com/test/image:latest"
+	digest := v1.Hash{Algorithm: "sha256", Hex: "deadbeef00000000000000000000000000000000000000000000000000000000"[:64]}
+
+	signer := NewKeySigner(SigningOptions{KeyPath: keyPath})
+
+	privateKey, err := signer.loadPrivateKey()
+	require.NoError(t, err)
+
+	payload, err := simpleSigningPayload(ref, digest)
+	require.NoError(t, err)
+
+	sig, err := signPayload(privateKey, payload)
+	require.NoError(t, err)
+
+	assert.NoError(t, signer.Verify(pub, ref, digest, sig))
+}
+
+func TestKeySigner_LoadPrivateKey_EncryptedKey(t *testing.T) {
+	key, keyPath := generateEncryptedTestKeyFile(t, "hunter2")
+	t.Setenv("COSIGN_PASSWORD", "hunter2")
+
+	signer := NewKeySigner(SigningOptions{KeyPath: keyPath, PasswordEnvVar: "COSIGN_PASSWORD"})
+
+	loaded, err := signer.loadPrivateKey()
+	require.NoError(t, err)
+
+	ecKey, ok := loaded.(*ecdsa.PrivateKey)
+	require.True(t, ok, "loaded key should be an *ecdsa.PrivateKey")
+	assert.True(t, key.Equal(ecKey))
+}
+
+func TestKeySigner_LoadPrivateKey_EncryptedKeyWrongPassword(t *testing.T) {
+	_, keyPath := generateEncryptedTestKeyFile(t, "hunter2")
+	t.Setenv("COSIGN_PASSWORD", "wrong-password")
+
+	signer := NewKeySigner(SigningOptions{KeyPath: keyPath, PasswordEnvVar: "COSIGN_PASSWORD"})
+
+	_, err := signer.loadPrivateKey()
+	assert.Error(t, err)
+}
+
+func TestKeySigner_LoadPrivateKey_EncryptedKeyMissingPasswordEnvVar(t *testing.T) {
+	_, keyPath := generateEncryptedTestKeyFile(t, "hunter2")
+
+	signer := NewKeySigner(SigningOptions{KeyPath: keyPath})
+
+	_, err := signer.loadPrivateKey()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "encrypted")
+}
+
+type mockSigner struct {
+	calls []string
+	err   error
+}
+
+func (m *mockSigner) SignImage(ctx context.Context, ref string, digest v1.Hash) error {
+	m.calls = append(m.calls, ref)
+	return m.err
+}
+
+func TestImageBuilder_SaveAndSign(t *testing.T) {
+	img := newTestImage(types.DockerManifestSchema2)
+
+	t.Run("does not sign when Sign is false", func(t *testing.T) {
+		mockIS := &mockImageSaver{}
+		mockIS.On("SaveImage", mock.Anything, mock.Anything, "example.com/test:latest").Return(nil)
+
+		signer := &mockSigner{}
+		b := &ImageBuilder{imageSaver: mockIS, signer: signer}
+
+		err := b.SaveAndSign(context.Background(), img, "example.com/test:latest", BuildOptions{})
+		assert.NoError(t, err)
+		assert.Empty(t, signer.calls)
+		mockIS.AssertExpectations(t)
+	})
+
+	t.Run("signs after a successful save", func(t *testing.T) {
+		mockIS := &mockImageSaver{}
+		mockIS.On("SaveImage", mock.Anything, mock.Anything, "example.com/test:latest").Return(nil)
+
+		signer := &mockSigner{}
+		b := &ImageBuilder{imageSaver: mockIS, signer: signer}
+
+		err := b.SaveAndSign(context.Background(), img, "example.com/test:latest", BuildOptions{Sign: true})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"example.com/test:latest"}, signer.calls)
+		mockIS.AssertExpectations(t)
+	})
+
+	t.Run("does not sign when save fails", func(t *testing.T) {
+		mockIS := &mockImageSaver{}
+		mockIS.On("SaveImage", mock.Anything, mock.Anything, "example.com/test:latest").Return(assert.AnError)
+
+		signer := &mockSigner{}
+		b := &ImageBuilder{imageSaver: mockIS, signer: signer}
+
+		err := b.SaveAndSign(context.Background(), img, "example.com/test:latest", BuildOptions{Sign: true})
+		assert.Error(t, err)
+		assert.Empty(t, signer.calls)
+	})
+}
@@ -0,0 +1,251 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/genmcp/gen-mcp/pkg/builder"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(buildCmd)
+	buildCmd.Flags().StringVar(&baseImage, "base-image", "", "base image to build the genmcp image on top of")
+	buildCmd.Flags().StringVarP(&mcpFile, "file", "f", "mcpfile.yaml", "mcp file to build")
+	buildCmd.Flags().StringVar(&platform, "platform", "linux/amd64", "comma-separated list of platforms to build genmcp for, e.g. linux/amd64,linux/arm64")
+	buildCmd.Flags().StringVar(&imageTag, "tag", "", "image tag for the registry")
+	buildCmd.Flags().BoolVar(&push, "push", false, "push the image to the registry (if false, store locally)")
+	buildCmd.Flags().BoolVar(&sign, "sign", false, "sign the pushed image with cosign-compatible signing (requires --push and --key)")
+	buildCmd.Flags().StringVar(&signingKey, "key", "", "path to the PEM-encoded private key used to sign the image")
+	buildCmd.Flags().StringSliceVar(&encryptFor, "encrypt-for", nil, "path to a PEM-encoded RSA public key to encrypt the mcpfile.yaml layer for (repeatable)")
+	buildCmd.Flags().BoolVar(&encryptBinary, "encrypt-binary", false, "also encrypt the genmcp-server binary layer (requires --encrypt-for)")
+	buildCmd.Flags().StringVar(&output, "output", "", "where to write the image instead of --push/local container engine: docker-archive://path.tar, oci-archive://path.tar, or oci-layout://dir")
+	buildCmd.Flags().StringVar(&progress, "progress", "auto", "progress output format: 'auto' for human-readable text, or 'json' to stream docker build-compatible JSON-lines progress events to stdout")
+	buildCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "with --progress=json, suppress all events except the final image digest")
+}
+
+var buildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build container image and save locally or push to registry",
+	Run:   executeBuildCmd,
+}
+
+var (
+	baseImage     string
+	mcpFile       string
+	platform      string
+	imageTag      string
+	push          bool
+	sign          bool
+	signingKey    string
+	encryptFor    []string
+	encryptBinary bool
+	output        string
+	progress      string
+	quiet         bool
+)
+
+func executeBuildCmd(cobraCmd *cobra.Command, args []string) {
+	ctx := cobraCmd.Context()
+
+	if imageTag == "" {
+		fmt.Printf("--tag is required to build an image\n")
+		os.Exit(1)
+	}
+
+	if sign && !push {
+		fmt.Printf("--sign requires --push\n")
+		os.Exit(1)
+	}
+
+	if output != "" && push {
+		fmt.Printf("--output cannot be combined with --push\n")
+		os.Exit(1)
+	}
+
+	if output != "" && sign {
+		fmt.Printf("--output cannot be combined with --sign\n")
+		os.Exit(1)
+	}
+
+	if progress != "auto" && progress != "json" {
+		fmt.Printf("--progress must be 'auto' or 'json'\n")
+		os.Exit(1)
+	}
+
+	if quiet && progress != "json" {
+		fmt.Printf("--quiet requires --progress=json\n")
+		os.Exit(1)
+	}
+
+	if encryptBinary && len(encryptFor) == 0 {
+		fmt.Printf("--encrypt-binary requires --encrypt-for\n")
+		os.Exit(1)
+	}
+
+	recipients := make([]string, 0, len(encryptFor))
+	for _, keyPath := range encryptFor {
+		keyPEM, err := os.ReadFile(keyPath)
+		if err != nil {
+			fmt.Printf("failed to read recipient public key %s: %s\n", keyPath, err.Error())
+			os.Exit(1)
+		}
+		recipients = append(recipients, string(keyPEM))
+	}
+
+	platforms := strings.Split(platform, ",")
+	b := builder.New(push)
+	jsonProgress := progress == "json"
+
+	if len(platforms) == 1 {
+		parsedPlatform, err := v1.ParsePlatform(platforms[0])
+		if err != nil {
+			fmt.Printf("failed to parse platform flag\n")
+			os.Exit(1)
+		}
+
+		opts := builder.BuildOptions{
+			Platform:    parsedPlatform,
+			BaseImage:   baseImage,
+			MCPFilePath: mcpFile,
+			ImageTag:    imageTag,
+			Sign:        sign,
+			SigningOptions: builder.SigningOptions{
+				KeyPath: signingKey,
+			},
+			EncryptionConfig: builder.EncryptionConfig{
+				Recipients:    recipients,
+				EncryptBinary: encryptBinary,
+			},
+			Output: output,
+		}
+
+		if jsonProgress {
+			opts.ProgressWriter = os.Stdout
+			opts.SuppressOutput = quiet
+		} else {
+			fmt.Printf("building image...\n")
+		}
+
+		img, err := b.Build(ctx, opts)
+		if err != nil {
+			fmt.Printf("failed to build image: %s\n", err.Error())
+			os.Exit(1)
+		}
+
+		if !jsonProgress {
+			switch {
+			case output != "":
+				fmt.Printf("successfully built image!\nsaving image to %s...\n", output)
+			case push:
+				fmt.Printf("successfully built image!\npushing image to %s...\n", imageTag)
+			default:
+				fmt.Printf("successfully built image!\nsaving image to local container engine as %s...\n", imageTag)
+			}
+		}
+
+		if err := b.SaveAndSign(ctx, img, imageTag, opts); err != nil {
+			switch {
+			case output != "":
+				fmt.Printf("failed to save image to %s: %s\n", output, err.Error())
+			case push:
+				fmt.Printf("failed to push image - ensure you are logged in: %s\n", err.Error())
+			default:
+				fmt.Printf("failed to save image to local container engine: %s\n", err.Error())
+			}
+			os.Exit(1)
+		}
+
+		if !jsonProgress {
+			switch {
+			case output != "":
+				fmt.Printf("successfully saved image to %s\n", output)
+			case push:
+				fmt.Printf("successfully pushed %s\n", imageTag)
+				if sign {
+					fmt.Printf("successfully signed %s\n", imageTag)
+				}
+			default:
+				fmt.Printf("successfully saved %s to local container engine\n", imageTag)
+			}
+		}
+		return
+	}
+
+	if sign {
+		fmt.Printf("--sign is not yet supported for multi-platform builds\n")
+		os.Exit(1)
+	}
+
+	if len(recipients) > 0 {
+		fmt.Printf("--encrypt-for is not yet supported for multi-platform builds\n")
+		os.Exit(1)
+	}
+
+	parsedPlatforms := make([]*v1.Platform, 0, len(platforms))
+	for _, p := range platforms {
+		parsed, err := v1.ParsePlatform(strings.TrimSpace(p))
+		if err != nil {
+			fmt.Printf("failed to parse platform '%s': %s\n", p, err.Error())
+			os.Exit(1)
+		}
+		parsedPlatforms = append(parsedPlatforms, parsed)
+	}
+
+	opts := builder.MultiArchBuildOptions{
+		Platforms:   parsedPlatforms,
+		BaseImage:   baseImage,
+		MCPFilePath: mcpFile,
+		ImageTag:    imageTag,
+		Output:      output,
+	}
+
+	if jsonProgress {
+		opts.ProgressWriter = os.Stdout
+		opts.SuppressOutput = quiet
+	} else {
+		fmt.Printf("building image index for platforms: %s...\n", platform)
+	}
+
+	idx, err := b.BuildMultiArch(ctx, opts)
+	if err != nil {
+		fmt.Printf("failed to build image index: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	if !jsonProgress {
+		switch {
+		case output != "":
+			fmt.Printf("successfully built image index!\nsaving image index to %s...\n", output)
+		case push:
+			fmt.Printf("successfully built image index!\npushing image index to %s...\n", imageTag)
+		default:
+			fmt.Printf("successfully built image index!\nsaving images to local container engine...\n")
+		}
+	}
+
+	if err := b.SaveIndexTo(ctx, idx, imageTag, opts); err != nil {
+		switch {
+		case output != "":
+			fmt.Printf("failed to save image index to %s: %s\n", output, err.Error())
+		case push:
+			fmt.Printf("failed to push image index - ensure you are logged in: %s\n", err.Error())
+		default:
+			fmt.Printf("failed to save images to local container engine: %s\n", err.Error())
+		}
+		os.Exit(1)
+	}
+
+	if !jsonProgress {
+		switch {
+		case output != "":
+			fmt.Printf("successfully saved image index to %s\n", output)
+		case push:
+			fmt.Printf("successfully pushed %s\n", imageTag)
+		default:
+			fmt.Printf("successfully saved %s to local container engine\n", imageTag)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package mcpfile
+
+// chainedOverrider applies a sequence of RuntimeOverriders in order, so a
+// later overrider's values win over an earlier one's for any field they
+// both set.
+type chainedOverrider struct {
+	overriders []RuntimeOverrider
+}
+
+// ChainOverriders returns a RuntimeOverrider that applies each of overriders
+// in order. This lets callers layer override sources - e.g. a base config
+// file, then a Kubernetes secret mount, then environment variables for
+// last-word precedence - while keeping NewEnvRuntimeOverrider usable on its
+// own for backward compatibility.
+func ChainOverriders(overriders ...RuntimeOverrider) RuntimeOverrider {
+	return &chainedOverrider{overriders: overriders}
+}
+
+func (c *chainedOverrider) ApplyOverrides(runtime *ServerRuntime) error {
+	for _, overrider := range c.overriders {
+		if err := overrider.ApplyOverrides(runtime); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
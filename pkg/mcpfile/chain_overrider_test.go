@@ -0,0 +1,51 @@
+package mcpfile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubOverrider struct {
+	apply func(runtime *ServerRuntime) error
+}
+
+func (s *stubOverrider) ApplyOverrides(runtime *ServerRuntime) error {
+	return s.apply(runtime)
+}
+
+func TestChainOverriders_LaterWins(t *testing.T) {
+	runtime := &ServerRuntime{TransportProtocol: "streamablehttp"}
+
+	chain := ChainOverriders(
+		&stubOverrider{apply: func(r *ServerRuntime) error {
+			r.TransportProtocol = "stdio"
+			return nil
+		}},
+		&stubOverrider{apply: func(r *ServerRuntime) error {
+			r.TransportProtocol = "streamablehttp"
+			return nil
+		}},
+	)
+
+	assert.NoError(t, chain.ApplyOverrides(runtime))
+	assert.Equal(t, "streamablehttp", runtime.TransportProtocol)
+}
+
+func TestChainOverriders_StopsOnFirstError(t *testing.T) {
+	var secondCalled bool
+
+	chain := ChainOverriders(
+		&stubOverrider{apply: func(r *ServerRuntime) error {
+			return assert.AnError
+		}},
+		&stubOverrider{apply: func(r *ServerRuntime) error {
+			secondCalled = true
+			return nil
+		}},
+	)
+
+	err := chain.ApplyOverrides(&ServerRuntime{})
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.False(t, secondCalled, "overriders after the failing one should not run")
+}
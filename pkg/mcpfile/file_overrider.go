@@ -0,0 +1,37 @@
+package mcpfile
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// FileRuntimeOverrider applies a JSON or YAML patch file to a ServerRuntime.
+// The file only needs to set the fields it wants to override - since it's
+// unmarshalled directly onto the existing runtime, fields it omits keep
+// whatever value they already had.
+type FileRuntimeOverrider struct {
+	Path string
+}
+
+func NewFileRuntimeOverrider(path string) RuntimeOverrider {
+	return &FileRuntimeOverrider{Path: path}
+}
+
+func (f *FileRuntimeOverrider) ApplyOverrides(runtime *ServerRuntime) error {
+	if runtime == nil {
+		return fmt.Errorf("can only apply file overrides to a non-nil server runtime")
+	}
+
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read runtime override file %s: %w", f.Path, err)
+	}
+
+	if err := yaml.Unmarshal(data, runtime); err != nil {
+		return fmt.Errorf("failed to parse runtime override file %s: %w", f.Path, err)
+	}
+
+	return nil
+}
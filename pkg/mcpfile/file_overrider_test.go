@@ -0,0 +1,44 @@
+package mcpfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileRuntimeOverrider_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"streamableHttpConfig":{"port":9000}}`), 0644))
+
+	runtime := &ServerRuntime{
+		TransportProtocol:    "streamablehttp",
+		StreamableHTTPConfig: &StreamableHTTPConfig{Port: 8080, BasePath: "/mcp"},
+	}
+
+	overrider := NewFileRuntimeOverrider(path)
+	require.NoError(t, overrider.ApplyOverrides(runtime))
+
+	assert.Equal(t, 9000, runtime.StreamableHTTPConfig.Port)
+	assert.Equal(t, "/mcp", runtime.StreamableHTTPConfig.BasePath, "fields absent from the patch file should be left untouched")
+}
+
+func TestFileRuntimeOverrider_YAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("transportProtocol: stdio\n"), 0644))
+
+	runtime := &ServerRuntime{TransportProtocol: "streamablehttp"}
+
+	overrider := NewFileRuntimeOverrider(path)
+	require.NoError(t, overrider.ApplyOverrides(runtime))
+
+	assert.Equal(t, "stdio", runtime.TransportProtocol)
+}
+
+func TestFileRuntimeOverrider_MissingFile(t *testing.T) {
+	overrider := NewFileRuntimeOverrider(filepath.Join(t.TempDir(), "missing.yaml"))
+	err := overrider.ApplyOverrides(&ServerRuntime{})
+	assert.Error(t, err)
+}
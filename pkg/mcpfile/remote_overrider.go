@@ -0,0 +1,93 @@
+package mcpfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// RemoteOverrider fetches a JSON override document over HTTP and applies it
+// the same way FileRuntimeOverrider applies a local one. It caches the last
+// response's ETag and sends it back as If-None-Match on every subsequent
+// fetch, so a server that hasn't changed its override document only ever
+// costs a 304 Not Modified round trip.
+type RemoteOverrider struct {
+	URL string
+	// Client is used to perform the HTTP request. Defaults to
+	// http.DefaultClient when nil.
+	Client *http.Client
+
+	mu         sync.Mutex
+	etag       string
+	cachedBody []byte
+}
+
+func NewRemoteOverrider(url string) RuntimeOverrider {
+	return &RemoteOverrider{URL: url}
+}
+
+func (r *RemoteOverrider) httpClient() *http.Client {
+	if r.Client != nil {
+		return r.Client
+	}
+	return http.DefaultClient
+}
+
+func (r *RemoteOverrider) ApplyOverrides(runtime *ServerRuntime) error {
+	if runtime == nil {
+		return fmt.Errorf("can only apply remote overrides to a non-nil server runtime")
+	}
+
+	body, err := r.fetch()
+	if err != nil {
+		return fmt.Errorf("failed to fetch runtime overrides from %s: %w", r.URL, err)
+	}
+
+	if err := json.Unmarshal(body, runtime); err != nil {
+		return fmt.Errorf("failed to parse runtime overrides from %s: %w", r.URL, err)
+	}
+
+	return nil
+}
+
+// fetch returns the override document body, using the cached copy without
+// a network round trip if the server confirms it's still current via a 304
+// Not Modified response to an If-None-Match request.
+func (r *RemoteOverrider) fetch() ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	req, err := http.NewRequest(http.MethodGet, r.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if r.etag != "" {
+		req.Header.Set("If-None-Match", r.etag)
+	}
+
+	resp, err := r.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return r.cachedBody, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.etag = resp.Header.Get("ETag")
+	r.cachedBody = body
+
+	return body, nil
+}
@@ -0,0 +1,64 @@
+package mcpfile
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteOverrider_AppliesDocument(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"transportProtocol":"stdio"}`))
+	}))
+	defer server.Close()
+
+	runtime := &ServerRuntime{TransportProtocol: "streamablehttp"}
+	overrider := NewRemoteOverrider(server.URL)
+	require.NoError(t, overrider.ApplyOverrides(runtime))
+
+	assert.Equal(t, "stdio", runtime.TransportProtocol)
+	assert.Equal(t, 1, requests)
+}
+
+func TestRemoteOverrider_UsesETagCache(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"transportProtocol":"stdio"}`))
+	}))
+	defer server.Close()
+
+	overrider := NewRemoteOverrider(server.URL)
+
+	first := &ServerRuntime{TransportProtocol: "streamablehttp"}
+	require.NoError(t, overrider.ApplyOverrides(first))
+	assert.Equal(t, "stdio", first.TransportProtocol)
+
+	second := &ServerRuntime{TransportProtocol: "streamablehttp"}
+	require.NoError(t, overrider.ApplyOverrides(second))
+	assert.Equal(t, "stdio", second.TransportProtocol, "a cached 304 response should still apply the last known document")
+
+	assert.Equal(t, 2, requests, "the second call should still hit the server to revalidate, just not re-transfer the body")
+}
+
+func TestRemoteOverrider_UnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	overrider := NewRemoteOverrider(server.URL)
+	err := overrider.ApplyOverrides(&ServerRuntime{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package mcpfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// SecretDirOverrider reads override values from files under a mounted
+// directory, one file per key, the way Kubernetes projects Secrets and
+// ConfigMaps into a pod. Each filename is the same dotted key
+// NewEnvRuntimeOverrider reads from the environment (e.g.
+// "GENMCP_STREAMABLEHTTPCONFIG_PORT"), and the file's contents are the
+// override value.
+//
+// Kubernetes publishes updates to a projected volume by atomically swapping
+// a "..data" symlink to point at a new timestamped directory; every entry
+// under Dir is itself a symlink into "..data/<key>". os.ReadFile follows
+// symlinks transparently, so reading through Dir always sees the current
+// data - the only thing SecretDirOverrider needs to do is skip Kubernetes'
+// own dot-prefixed bookkeeping entries ("..data", "..2024_01_01_..._/", etc).
+type SecretDirOverrider struct {
+	Dir string
+}
+
+func NewSecretDirOverrider(dir string) RuntimeOverrider {
+	return &SecretDirOverrider{Dir: dir}
+}
+
+func (s *SecretDirOverrider) ApplyOverrides(runtime *ServerRuntime) error {
+	if runtime == nil {
+		return fmt.Errorf("can only apply secret dir overrides to a non-nil server runtime")
+	}
+
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return fmt.Errorf("failed to read secret dir %s: %w", s.Dir, err)
+	}
+
+	values := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.Dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to read secret file %s: %w", entry.Name(), err)
+		}
+
+		values[strings.ToUpper(entry.Name())] = strings.TrimRight(string(data), "\n")
+	}
+
+	reflectRuntime := reflect.ValueOf(runtime).Elem()
+	_, err = processStruct(reflectRuntime, genmcpEnvPrefix, func(key string) (string, bool) {
+		v, ok := values[key]
+		return v, ok
+	})
+
+	return err
+}
@@ -0,0 +1,69 @@
+package mcpfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretDirOverrider(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "GENMCP_TRANSPORTPROTOCOL"), []byte("stdio\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "GENMCP_STREAMABLEHTTPCONFIG_PORT"), []byte("9000"), 0644))
+
+	runtime := &ServerRuntime{
+		TransportProtocol:    "streamablehttp",
+		StreamableHTTPConfig: &StreamableHTTPConfig{Port: 8080},
+	}
+
+	overrider := NewSecretDirOverrider(dir)
+	require.NoError(t, overrider.ApplyOverrides(runtime))
+
+	assert.Equal(t, "stdio", runtime.TransportProtocol)
+	assert.Equal(t, 9000, runtime.StreamableHTTPConfig.Port)
+}
+
+// TestSecretDirOverrider_AtomicDataSymlinkSwap mirrors how Kubernetes
+// projects a Secret/ConfigMap volume: every visible entry is a symlink into
+// a hidden, timestamped "..data"-style directory, and updates are published
+// by atomically repointing a top-level "..data" symlink at a new such
+// directory. SecretDirOverrider must resolve through that symlink and must
+// skip the dot-prefixed bookkeeping entries themselves.
+func TestSecretDirOverrider_AtomicDataSymlinkSwap(t *testing.T) {
+	dir := t.TempDir()
+
+	dataV1 := filepath.Join(dir, "..data_v1")
+	require.NoError(t, os.Mkdir(dataV1, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dataV1, "GENMCP_TRANSPORTPROTOCOL"), []byte("stdio"), 0644))
+
+	require.NoError(t, os.Symlink(dataV1, filepath.Join(dir, "..data")))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "GENMCP_TRANSPORTPROTOCOL"), filepath.Join(dir, "GENMCP_TRANSPORTPROTOCOL")))
+
+	runtime := &ServerRuntime{TransportProtocol: "streamablehttp"}
+	overrider := NewSecretDirOverrider(dir)
+	require.NoError(t, overrider.ApplyOverrides(runtime))
+	assert.Equal(t, "stdio", runtime.TransportProtocol)
+
+	// Kubernetes publishes an update by creating a new data directory and
+	// atomically swapping the "..data" symlink to point at it.
+	dataV2 := filepath.Join(dir, "..data_v2")
+	require.NoError(t, os.Mkdir(dataV2, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dataV2, "GENMCP_TRANSPORTPROTOCOL"), []byte("streamablehttp"), 0644))
+
+	tmpLink := filepath.Join(dir, "..data_tmp")
+	require.NoError(t, os.Symlink(dataV2, tmpLink))
+	require.NoError(t, os.Rename(tmpLink, filepath.Join(dir, "..data")))
+
+	runtime2 := &ServerRuntime{TransportProtocol: "stdio"}
+	require.NoError(t, overrider.ApplyOverrides(runtime2))
+	assert.Equal(t, "streamablehttp", runtime2.TransportProtocol, "override should follow the swapped ..data symlink")
+}
+
+func TestSecretDirOverrider_MissingDir(t *testing.T) {
+	overrider := NewSecretDirOverrider(filepath.Join(t.TempDir(), "missing"))
+	err := overrider.ApplyOverrides(&ServerRuntime{})
+	assert.Error(t, err)
+}